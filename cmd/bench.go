@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/bench"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var benchFlags struct {
+	algorithms  []string
+	sizes       []string
+	duration    time.Duration
+	parallelism int
+	json        bool
+	interactive bool
+}
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Measure hashing throughput of every algorithm",
+	Long: `Measure in-memory throughput in MB/s (10^6 bytes per second) for each
+algorithm across message sizes from 64 B to 1 GiB, first on a single core
+and then with --parallel workers hashing independent messages.
+
+Password hashes are skipped; their cost is deliberate. Algorithms forbidden
+by the active policy are skipped too. The full run takes a few minutes; use
+--sizes and -a to narrow it.`,
+	Example: `  hashctl bench
+  hashctl bench -a sha256,blake2b-256 --sizes 1K,1M --json
+  hashctl bench --tui`,
+	Args: cobra.NoArgs,
+	RunE: runBench,
+}
+
+func init() {
+	cfg := bench.DefaultConfig()
+	var sizes []string
+	for _, s := range cfg.Sizes {
+		sizes = append(sizes, strings.ReplaceAll(bench.FormatSize(s), " ", ""))
+	}
+
+	f := benchCmd.Flags()
+	f.StringSliceVarP(&benchFlags.algorithms, "algorithm", "a", nil, "algorithms to measure (default all)")
+	f.StringSliceVar(&benchFlags.sizes, "sizes", sizes, "message sizes")
+	f.DurationVar(&benchFlags.duration, "duration", cfg.Duration, "minimum time per measurement")
+	f.IntVarP(&benchFlags.parallelism, "parallel", "p", cfg.Parallelism, "workers for the parallel pass (1 skips it)")
+	f.BoolVar(&benchFlags.json, "json", false, "print measurements as JSON")
+	f.BoolVar(&benchFlags.interactive, "tui", false, "show live throughput bars")
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	cfg := bench.DefaultConfig()
+	cfg.Duration = benchFlags.duration
+	cfg.Parallelism = benchFlags.parallelism
+
+	if len(benchFlags.algorithms) > 0 {
+		cfg.Algorithms = nil
+		for _, a := range benchFlags.algorithms {
+			if err := checkAlgorithm(a); err != nil {
+				return printErr(err)
+			}
+			cfg.Algorithms = append(cfg.Algorithms, a)
+		}
+	} else {
+		var allowed []string
+		for _, a := range cfg.Algorithms {
+			if activePolicy.Allowed(a) {
+				allowed = append(allowed, a)
+			}
+		}
+		cfg.Algorithms = allowed
+	}
+
+	cfg.Sizes = nil
+	for _, s := range benchFlags.sizes {
+		n, err := bench.ParseSize(s)
+		if err != nil {
+			return printErr(err)
+		}
+		cfg.Sizes = append(cfg.Sizes, n)
+	}
+
+	if benchFlags.interactive {
+		return tui.RunBench(cfg)
+	}
+
+	progress := func(bench.Measurement) {}
+	if tui.IsTerminal(os.Stderr) {
+		done := 0
+		progress = func(m bench.Measurement) {
+			done++
+			fmt.Fprintf(os.Stderr, "\r\033[K%s", tui.MutedStyle.Render(fmt.Sprintf("%d/%d %s %s", done, cfg.Runs(), m.Algorithm, bench.FormatSize(m.Size))))
+		}
+	}
+
+	results, err := bench.Run(cfg, progress)
+	if tui.IsTerminal(os.Stderr) {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+	if err != nil {
+		return printErr(err)
+	}
+
+	if benchFlags.json {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+
+	printBenchTable(cfg, results, 1)
+	if cfg.Parallelism > 1 {
+		printBenchTable(cfg, results, cfg.Parallelism)
+	}
+	return nil
+}
+
+// printBenchTable prints MB/s with one row per algorithm and one column per size
+func printBenchTable(cfg bench.Config, results []bench.Measurement, workers int) {
+	mbps := make(map[string]map[int64]float64)
+	for _, r := range results {
+		if r.Workers != workers {
+			continue
+		}
+		if mbps[r.Algorithm] == nil {
+			mbps[r.Algorithm] = make(map[int64]float64)
+		}
+		mbps[r.Algorithm][r.Size] = r.MBps
+	}
+
+	title := "single core"
+	if workers > 1 {
+		title = fmt.Sprintf("%d workers", workers)
+	}
+	fmt.Println()
+	fmt.Println(tui.LogoStyle.Render("hashctl") + tui.LogoAccent.Render(" bench ") +
+		tui.MutedStyle.Render(fmt.Sprintf("%s • MB/s • %s/%s", title, runtime.GOOS, runtime.GOARCH)))
+	fmt.Println()
+
+	header := fmt.Sprintf("%-14s", "")
+	for _, size := range cfg.Sizes {
+		header += fmt.Sprintf("%10s", bench.FormatSize(size))
+	}
+	fmt.Println(tui.MutedStyle.Render(header))
+
+	for _, alg := range cfg.Algorithms {
+		line := ""
+		for _, size := range cfg.Sizes {
+			line += fmt.Sprintf("%10.1f", mbps[alg][size])
+		}
+		fmt.Println(tui.LabelStyle.Render(fmt.Sprintf("%-14s", alg)) + tui.ValueStyle.Render(line))
+	}
+}
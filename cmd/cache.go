@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/cache"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var cachePruneOlderThan time.Duration
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and prune the digest cache",
+	Long: `The digest cache is opt-in: pass --cache (or set HASHCTL_CACHE=file) to
+reuse digests of files whose size, mtime, ctime and inode are unchanged.
+These commands operate on the file store; xattr entries live on the files
+themselves and disappear with them. Writing an xattr changes the file's
+ctime, so --cache=xattr alone checks only size, mtime and inode; use
+--cache=both to have ctime checked as well.`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache location, size and entry counts",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheStats,
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove entries for missing or changed files",
+	Args:  cobra.NoArgs,
+	RunE:  runCachePrune,
+}
+
+func init() {
+	cachePruneCmd.Flags().DurationVar(&cachePruneOlderThan, "older-than", 0, "also remove entries unused for this long, e.g. 720h")
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
+}
+
+// openCacheStore opens the file store, warning when it is corrupt
+func openCacheStore() (*cache.FileStore, error) {
+	store, err := cache.OpenFile(cache.DefaultPath())
+	if errors.Is(err, cache.ErrCorrupt) {
+		warnCorruptCache(err)
+		return store, nil
+	}
+	return store, err
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	store, err := openCacheStore()
+	if err != nil {
+		return printErr(err)
+	}
+	st := store.Stats()
+
+	label := tui.MutedStyle
+	value := tui.ValueStyle
+
+	fmt.Println()
+	fmt.Println(label.Render("path      ") + value.Render(st.Path))
+	fmt.Println(label.Render("size      ") + value.Render(tui.FormatBytes(st.Bytes)))
+	fmt.Println(label.Render("entries   ") + value.Render(fmt.Sprintf("%d", st.Entries)))
+	fmt.Println(label.Render("stale     ") + value.Render(fmt.Sprintf("%d", st.Stale)))
+
+	algs := make([]string, 0, len(st.ByAlgorithm))
+	for alg := range st.ByAlgorithm {
+		algs = append(algs, alg)
+	}
+	sort.Strings(algs)
+	for _, alg := range algs {
+		fmt.Println(label.Render(fmt.Sprintf("  %-8s", alg)) + value.Render(fmt.Sprintf("%d", st.ByAlgorithm[alg])))
+	}
+	fmt.Println()
+	return nil
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	store, err := openCacheStore()
+	if err != nil {
+		return printErr(err)
+	}
+	removed, err := store.Prune(cachePruneOlderThan)
+	if err != nil {
+		return printErr(err)
+	}
+	fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("✓ removed %d entries", removed)))
+	return nil
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/atharvamhaske/hashctl/internal/config"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// configEnv points at a different settings file, e.g. in CI
+const configEnv = "HASHCTL_CONFIG"
+
+// Settings are resolved before any command runs: the settings file,
+// overridden by HASHCTL_* environment variables. Flags win over both.
+var (
+	configPath   string
+	fileSettings config.Config
+	envSettings  config.Config
+	settings     config.Config
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show or change saved settings",
+	Long: `Settings saved here are defaults for the TUI and the commands: the
+algorithm, output format, parallelism, password hash parameters, theme and
+whether 'hashctl version' checks for updates. They live in
+$XDG_CONFIG_HOME/hashctl/config.json (or $` + configEnv + `) and can also be
+changed in the TUI with ctrl+o.
+
+Command-line flags win over environment variables such as
+HASHCTL_ALGORITHM, which win over the file.`,
+	Example: `  hashctl config
+  hashctl config set algorithm blake2b-256
+  hashctl config set parallelism 4
+  hashctl config set theme high-contrast
+  hashctl config unset algorithm`,
+	Args: cobra.NoArgs,
+	RunE: runConfig,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Save a setting",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a setting, restoring its default",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSet(cmd, []string{args[0], ""})
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+}
+
+// setupConfig loads the settings file and the environment. A broken file
+// is reported and ignored rather than stopping every command.
+func setupConfig() error {
+	configPath = os.Getenv(configEnv)
+	if configPath == "" {
+		configPath = config.DefaultPath()
+	}
+
+	var err error
+	fileSettings, err = config.Load(configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ ignoring settings: "+err.Error()))
+	}
+	envSettings, err = config.FromEnv(os.Getenv)
+	if err != nil {
+		return err
+	}
+	settings = fileSettings.Merge(envSettings)
+
+	if err := tui.LoadThemes(tui.ThemeDir(configPath)); err != nil {
+		fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ "+err.Error()))
+	}
+	if err := tui.SetTheme(settings.Theme); err != nil {
+		fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ "+err.Error()))
+	}
+	return nil
+}
+
+// applySettings makes the settings the defaults of the running command's
+// flags. The flags are not marked as changed, so commands can still tell
+// whether the user passed them.
+func applySettings(cmd *cobra.Command) error {
+	defaults := map[string]string{
+		"algorithm": settings.Get(config.KeyAlgorithm),
+		"parallel":  settings.Get(config.KeyParallelism),
+	}
+	if cmd == hashCmd {
+		defaults["format"] = settings.Get(config.KeyFormat)
+	}
+	for name, value := range defaults {
+		f := cmd.Flags().Lookup(name)
+		// bench takes a list of algorithms and measures all by default
+		if f == nil || f.Changed || value == "" || f.Value.Type() == "stringSlice" {
+			continue
+		}
+		if err := f.Value.Set(value); err != nil {
+			return fmt.Errorf("setting %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// settingSource describes where the value of a setting comes from
+func settingSource(key string) string {
+	switch {
+	case envSettings.Get(key) != "":
+		return "env " + config.EnvVar(key)
+	case fileSettings.Get(key) != "":
+		return "config"
+	}
+	return "default"
+}
+
+func runConfig(cmd *cobra.Command, args []string) error {
+	defaults := config.Defaults()
+	defaults.Theme = tui.DefaultTheme
+	effective := defaults.Merge(settings)
+
+	fmt.Println(tui.MutedStyle.Render(configPath))
+	fmt.Println()
+	for _, key := range config.Keys {
+		value := effective.Get(key)
+		fmt.Println(tui.LabelStyle.Render(fmt.Sprintf("%-14s", key)) + " " +
+			tui.ValueStyle.Render(fmt.Sprintf("%-14s", value)) + " " +
+			tui.DimStyle.Render(settingSource(key)))
+	}
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+	c, err := config.Load(configPath)
+	if err != nil {
+		return printErr(err)
+	}
+	if err := c.Set(key, value); err != nil {
+		return printErr(err)
+	}
+	if key == config.KeyTheme {
+		if err := tui.SetTheme(value); err != nil {
+			return printErr(err)
+		}
+	}
+	if err := c.Save(configPath); err != nil {
+		return printErr(err)
+	}
+
+	if value == "" {
+		fmt.Println(tui.SuccessStyle.Render("✓ unset " + key))
+	} else {
+		fmt.Println(tui.SuccessStyle.Render("✓ " + key + " = " + value))
+	}
+	if env := envSettings.Get(key); env != "" {
+		fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ "+config.EnvVar(key)+"="+env+" overrides it"))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var decodeCmd = &cobra.Command{
+	Use:   "decode <multihash|cid>",
+	Short: "Decode a multihash or CID into algorithm and digest",
+	Long: `Decode a multihash (hex or base58btc) or a CID (CIDv0 "Qm…" or a
+multibase-prefixed CIDv1) and print the hash algorithm and raw digest.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDecode,
+}
+
+func runDecode(cmd *cobra.Command, args []string) error {
+	cid, err := hasher.ParseContentID(args[0])
+	if err != nil {
+		return printErr(err)
+	}
+
+	mh := cid.Multihash
+	algorithm := mh.Algorithm
+	if algorithm == "" {
+		algorithm = fmt.Sprintf("unknown (0x%x)", mh.Code)
+	}
+
+	label := tui.MutedStyle
+	value := tui.ValueStyle
+
+	fmt.Println()
+	if cid.Version > 0 || cid.Codec != 0 {
+		fmt.Println(label.Render("cid       ") + value.Render(fmt.Sprintf("v%d", cid.Version)))
+		fmt.Println(label.Render("codec     ") + value.Render(codecName(cid.Codec)))
+	}
+	fmt.Println(label.Render("algorithm ") + value.Render(algorithm))
+	fmt.Println(label.Render("code      ") + value.Render(fmt.Sprintf("0x%x", mh.Code)))
+	fmt.Println(label.Render("length    ") + value.Render(fmt.Sprintf("%d bytes", len(mh.Digest))))
+	fmt.Println(label.Render("digest    ") + tui.HashStyle.Render(hex.EncodeToString(mh.Digest)))
+	fmt.Println()
+	return nil
+}
+
+func codecName(codec uint64) string {
+	switch codec {
+	case hasher.CodecRaw:
+		return "raw (0x55)"
+	case hasher.CodecDagPB:
+		return "dag-pb (0x70)"
+	default:
+		return fmt.Sprintf("0x%x", codec)
+	}
+}
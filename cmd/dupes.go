@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/atharvamhaske/hashctl/internal/dupes"
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var dupesFlags struct {
+	algorithm   string
+	partialKB   positiveInt64
+	minSize     int64
+	script      string
+	json        bool
+	interactive bool
+}
+
+var dupesCmd = &cobra.Command{
+	Use:   "dupes <dirs...>",
+	Short: "Find duplicate files",
+	Long: `Find files with identical content below one or more directories.
+
+Files are grouped by size, then by a quick checksum of their first and last
+bytes, and only the remaining candidates are fully hashed. Hard links to the
+same file are not counted as duplicates.
+
+--script prints a dry-run shell script with every command commented out;
+nothing is modified by hashctl itself.`,
+	Example: `  hashctl dupes /srv/share
+  hashctl dupes ~/Downloads ~/Documents --script hardlink > dedupe.sh
+  hashctl dupes /srv/share --tui`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runDupes,
+}
+
+func init() {
+	cfg := dupes.DefaultConfig()
+	f := dupesCmd.Flags()
+	f.StringVarP(&dupesFlags.algorithm, "algorithm", "a", hasher.DefaultOptions().Algorithm, "algorithm for the full comparison hash")
+	dupesFlags.partialKB = positiveInt64(cfg.PartialSize / 1024)
+	f.Var(&dupesFlags.partialKB, "partial-kb", "KiB read from each end of a file for the quick checksum")
+	f.Int64Var(&dupesFlags.minSize, "min-size", cfg.MinSize, "ignore files smaller than this many bytes")
+	f.StringVar(&dupesFlags.script, "script", "", "print a dry-run script: hardlink or delete")
+	f.BoolVar(&dupesFlags.json, "json", false, "print duplicate sets as JSON")
+	f.BoolVar(&dupesFlags.interactive, "tui", false, "browse duplicate sets interactively")
+}
+
+func runDupes(cmd *cobra.Command, args []string) error {
+	opts, done := hashOptions()
+	defer done()
+	opts.Algorithm = dupesFlags.algorithm
+	if err := checkAlgorithm(opts.Algorithm); err != nil {
+		return printErr(err)
+	}
+
+	cfg := dupes.DefaultConfig()
+	cfg.PartialSize = int64(dupesFlags.partialKB) * 1024
+	cfg.MinSize = dupesFlags.minSize
+
+	groups, stats, err := dupes.Find(args, opts, cfg)
+	if err != nil {
+		return printErr(err)
+	}
+
+	switch {
+	case dupesFlags.interactive:
+		return tui.RunDupes(groups, stats)
+	case dupesFlags.script != "":
+		script, err := dupes.Script(groups, dupesFlags.script)
+		if err != nil {
+			return printErr(err)
+		}
+		fmt.Print(script)
+	case dupesFlags.json:
+		if groups == nil {
+			groups = []dupes.Group{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Groups []dupes.Group `json:"groups"`
+			Stats  dupes.Stats   `json:"stats"`
+		}{groups, stats})
+	default:
+		printDupes(groups, stats)
+	}
+	return nil
+}
+
+func printDupes(groups []dupes.Group, stats dupes.Stats) {
+	if len(groups) == 0 {
+		fmt.Println(tui.SuccessStyle.Render("✓ no duplicates found"))
+		return
+	}
+
+	for _, g := range groups {
+		fmt.Println(tui.LabelStyle.Render(fmt.Sprintf("%d × %s", len(g.Files), tui.FormatBytes(g.Size))) +
+			" " + tui.DimStyle.Render(g.Digest))
+		for _, f := range g.Files {
+			fmt.Println("  " + tui.ValueStyle.Render(f))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("%d duplicate sets, %s reclaimable (%d scanned, %d partially hashed, %d fully hashed)",
+		len(groups), tui.FormatBytes(stats.Wasted), stats.Scanned, stats.PartialHash, stats.FullHash)))
+}
+
+// positiveInt64 is an int64 flag that refuses zero and negative values
+type positiveInt64 int64
+
+func (v *positiveInt64) String() string { return strconv.FormatInt(int64(*v), 10) }
+func (v *positiveInt64) Type() string   { return "int" }
+
+func (v *positiveInt64) Set(s string) error {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return errors.New("not a number")
+	}
+	if n <= 0 {
+		return errors.New("must be greater than zero")
+	}
+	*v = positiveInt64(n)
+	return nil
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/fingerprint"
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var hashFlags struct {
+	algorithm   string
+	str         string
+	format      string
+	parallelism int
+	archive     bool
+	merkle      bool
+	expect      string
+	decode      string
+	lineEnding  string
+	visual      string
+}
+
+var hashCmd = &cobra.Command{
+	Use:   "hash [files...]",
+	Short: "Hash strings or files from the command line",
+	Long: `Hash a string or one or more files without launching the TUI.
+
+Output follows the sha256sum layout ("<digest>  <file>") so it can be
+piped into other tools. Use --format to emit multihashes or CIDv1s for
+content-addressed stores.
+
+With --expect, each input is compared against a published digest instead
+(hex in any case, base64, sha256:…, SRI or bcrypt). Without -a the
+algorithm is taken from the digest's prefix or length. Exits non-zero on
+a mismatch.
+
+--decode turns --string into exact bytes first: hex and base64 accept
+whitespace, escape understands \n, \t, \0, \xff and \u00e9. --line-ending
+rewrites line breaks in the string as lf, crlf or none.
+
+--visual draws each digest as a picture or words that are easier to compare
+by eye or read aloud: OpenSSH-style randomart (the default), coloured
+blocks, emoji or the PGP word list.`,
+	Example: `  hashctl hash -a sha256 release.tar.gz
+  hashctl hash -s "hello world" -a blake2b-256
+  hashctl hash --format cid small.json
+  hashctl hash --archive --merkle release.tar.gz
+  hashctl hash --expect 9f86d081884c7d65…0f00a08 download.iso
+  hashctl hash -s 'line one\r\n' --decode escape
+  hashctl hash -s 'deadbeef' --decode hex
+  hashctl hash --visual release.tar.gz
+  hashctl hash --visual=words -s "hello world"`,
+	RunE: runHash,
+}
+
+func init() {
+	opts := hasher.DefaultOptions()
+	f := hashCmd.Flags()
+	f.StringVarP(&hashFlags.algorithm, "algorithm", "a", opts.Algorithm, "hash algorithm (see 'hashctl list')")
+	f.StringVarP(&hashFlags.str, "string", "s", "", "hash this string instead of files")
+	f.StringVarP(&hashFlags.format, "format", "f", hasher.FormatHex, "output format: "+strings.Join(hasher.Formats, ", "))
+	f.IntVarP(&hashFlags.parallelism, "parallel", "p", opts.Parallelism, "number of files hashed concurrently")
+	f.BoolVar(&hashFlags.archive, "archive", false, "hash the entries inside tar, tar.gz, tar.bz2 and zip archives")
+	f.BoolVar(&hashFlags.merkle, "merkle", false, "with --archive, also print a compression-independent Merkle digest")
+	f.StringVar(&hashFlags.expect, "expect", "", "compare against this digest and report match or mismatch")
+	f.StringVar(&hashFlags.decode, "decode", hasher.DecodeText, "decode --string as: "+strings.Join(hasher.Decoders, ", "))
+	f.StringVar(&hashFlags.lineEnding, "line-ending", "", "rewrite line breaks in --string as: "+strings.Join(hasher.LineEndings, ", "))
+	f.StringVar(&hashFlags.visual, "visual", "", "also draw each digest as: "+strings.Join(fingerprint.Kinds, ", "))
+	f.Lookup("visual").NoOptDefVal = fingerprint.KindRandomart
+}
+
+func runHash(cmd *cobra.Command, args []string) error {
+	opts, done := hashOptions()
+	defer done()
+	opts.Algorithm = hashFlags.algorithm
+	if hashFlags.parallelism > 0 {
+		opts.Parallelism = hashFlags.parallelism
+	}
+	if !hashFlags.archive {
+		// Archive entries are not files on disk, so they are not recorded
+		defer recordHistory(&opts)()
+	}
+
+	if hashFlags.expect != "" {
+		return runHashExpect(cmd, args, opts)
+	}
+	if err := checkAlgorithm(opts.Algorithm); err != nil {
+		return printErr(err)
+	}
+	if err := checkVisual(opts.Algorithm); err != nil {
+		return printErr(err)
+	}
+	if !slices.Contains(hasher.Formats, hashFlags.format) {
+		return printErr(fmt.Errorf("unknown --format %q (use %s)", hashFlags.format, strings.Join(hasher.Formats, ", ")))
+	}
+	if hashFlags.merkle && hashFlags.format != hasher.FormatHex {
+		// The root is a digest of digests, not of any content a multihash
+		// or CID could address
+		return printErr(fmt.Errorf("--merkle prints a hex root and cannot be combined with --format %s", hashFlags.format))
+	}
+
+	if cmd.Flags().Changed("string") {
+		input, err := stringInput(cmd)
+		if err != nil {
+			return printErr(err)
+		}
+		r := hasher.HashString(input, opts)
+		if r.Error != nil {
+			return printErr(r.Error)
+		}
+		out, err := hasher.FormatResult(r, opts.Algorithm, hashFlags.format)
+		if err != nil {
+			return printErr(err)
+		}
+		fmt.Println(out)
+		printVisual(r, opts.Algorithm)
+		return nil
+	}
+
+	if len(args) == 0 {
+		return printErr(errors.New("no input: pass file paths or --string"))
+	}
+
+	failed := 0
+	printResult := func(r hasher.Result) {
+		out := r.Hash
+		err := r.Error
+		if err == nil {
+			out, err = hasher.FormatResult(r, opts.Algorithm, hashFlags.format)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("✗ "+r.Input+": "+err.Error()))
+			return
+		}
+		fmt.Printf("%s  %s\n", out, r.Input)
+		printVisual(r, opts.Algorithm)
+	}
+
+	if hashFlags.archive {
+		for _, archive := range args {
+			root, err := hasher.HashArchive(archive, opts, printResult)
+			if errors.Is(err, hasher.ErrIncompleteArchive) {
+				// The entries that failed are already reported and counted
+				if hashFlags.merkle {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("✗ "+archive+": "+err.Error()))
+				}
+				continue
+			}
+			if err != nil {
+				failed++
+				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("✗ "+archive+": "+err.Error()))
+				continue
+			}
+			if hashFlags.merkle {
+				fmt.Printf("%s  %s\n", root, hasher.ArchiveEntryName(archive, ""))
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d archive entries or archives failed", failed)
+		}
+		return nil
+	}
+
+	hasher.HashFiles(args, opts, printResult)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed", failed, len(args))
+	}
+	return nil
+}
+
+// runHashExpect compares inputs against the --expect digest
+func runHashExpect(cmd *cobra.Command, args []string, opts hasher.Options) error {
+	if hashFlags.archive {
+		return printErr(errors.New("--expect cannot be combined with --archive"))
+	}
+	if hashFlags.visual != "" {
+		return printErr(errors.New("--expect cannot be combined with --visual"))
+	}
+
+	expected, err := hasher.ParseExpected(hashFlags.expect)
+	if err != nil {
+		return printErr(err)
+	}
+
+	if !cmd.Flags().Changed("algorithm") && expected.Algorithm != "" {
+		opts.Algorithm = expected.Algorithm
+		if !expected.Explicit {
+			note := "assuming " + expected.Algorithm + " from the digest length"
+			if len(expected.Alternatives) > 0 {
+				note += " (also possible: " + strings.Join(expected.Alternatives, ", ") + "; pass -a to choose)"
+			}
+			fmt.Fprintln(os.Stderr, tui.MutedStyle.Render(note))
+		}
+	} else if expected.Explicit && expected.Algorithm != opts.Algorithm {
+		return printErr(fmt.Errorf("expected hash is %s but -a is %s", expected.Algorithm, opts.Algorithm))
+	}
+	if err := checkAlgorithm(opts.Algorithm); err != nil {
+		return printErr(err)
+	}
+
+	alg, _ := hasher.GetAlgorithm(opts.Algorithm)
+	if expected.Digest != nil && !alg.IsPasswordHash && alg.NewHash().Size() != len(expected.Digest) {
+		return printErr(fmt.Errorf("expected hash has %d bytes but %s produces %d",
+			len(expected.Digest), opts.Algorithm, alg.NewHash().Size()))
+	}
+
+	mismatches := 0
+	report := func(r hasher.Result, label string) {
+		match, err := expected.Match(r, opts)
+		if err != nil {
+			mismatches++
+			fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("✗ "+label+": "+err.Error()))
+			return
+		}
+		fmt.Println(tui.Verdict(match, label))
+		if match || expected.Digest == nil {
+			if !match {
+				mismatches++
+			}
+			return
+		}
+		mismatches++
+		want, got := tui.HighlightDiff(expected.Hex(), r.Hash)
+		fmt.Println(tui.MutedStyle.Render("  expected ") + want)
+		fmt.Println(tui.MutedStyle.Render("  actual   ") + got)
+	}
+
+	if cmd.Flags().Changed("string") {
+		input, err := stringInput(cmd)
+		if err != nil {
+			return printErr(err)
+		}
+		var r hasher.Result
+		if expected.Digest == nil {
+			// Password hashes are verified against the input, not recomputed
+			r = hasher.Result{Input: input}
+		} else {
+			r = hasher.HashString(input, opts)
+		}
+		report(r, "(string)")
+	} else {
+		if len(args) == 0 {
+			return printErr(errors.New("no input: pass file paths or --string"))
+		}
+		hasher.HashFiles(args, opts, func(r hasher.Result) {
+			report(r, r.Input)
+		})
+	}
+
+	if mismatches > 0 {
+		return fmt.Errorf("%d inputs did not match", mismatches)
+	}
+	return nil
+}
+
+// checkVisual rejects a --visual kind that cannot be drawn here
+func checkVisual(algorithm string) error {
+	switch {
+	case hashFlags.visual == "":
+		return nil
+	case !slices.Contains(fingerprint.Kinds, hashFlags.visual):
+		return fmt.Errorf("unknown --visual %q (use %s)", hashFlags.visual, strings.Join(fingerprint.Kinds, ", "))
+	case hashFlags.visual == fingerprint.KindBlocks && !tui.ColorEnabled():
+		return errors.New("--visual=blocks needs a colour terminal; try randomart or words")
+	}
+	if alg, ok := hasher.GetAlgorithm(algorithm); ok && alg.IsPasswordHash {
+		return fmt.Errorf("--visual needs a digest, but %s output is salted", alg.Name)
+	}
+	return nil
+}
+
+// printVisual draws the --visual fingerprint below a digest
+func printVisual(r hasher.Result, algorithm string) {
+	if hashFlags.visual == "" {
+		return
+	}
+	fp, err := tui.Fingerprint(hashFlags.visual, algorithm, r.Hash)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("✗ "+r.Input+": "+err.Error()))
+		return
+	}
+	fmt.Println(fp)
+	fmt.Println()
+}
+
+// stringInput applies --line-ending and --decode to --string. When either
+// is given, the exact byte count goes to stderr so it can be checked.
+func stringInput(cmd *cobra.Command) (string, error) {
+	s := hashFlags.str
+	if hashFlags.lineEnding != "" {
+		var err error
+		if s, err = hasher.ApplyLineEnding(s, hashFlags.lineEnding); err != nil {
+			return "", err
+		}
+	}
+	data, err := hasher.DecodeInput(s, hashFlags.decode)
+	if err != nil {
+		return "", err
+	}
+	if cmd.Flags().Changed("decode") || cmd.Flags().Changed("line-ending") {
+		fmt.Fprintln(os.Stderr, tui.MutedStyle.Render(fmt.Sprintf("%d bytes", len(data))))
+	}
+	return string(data), nil
+}
+
+// printErr reports an error on stderr and returns it for the exit status
+func printErr(err error) error {
+	fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("✗ "+err.Error()))
+	return err
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/fuzzy"
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/history"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// historyEnv turns the history off without flags, e.g. HASHCTL_HISTORY=off
+const historyEnv = "HASHCTL_HISTORY"
+
+var historyFlags struct {
+	disabled bool
+	limit    int
+	json     bool
+	format   string
+	output   string
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history [query]",
+	Short: "Show, search, export or clear the hash history",
+	Long: `hashctl remembers digests computed by 'hashctl hash' and the TUI: the
+algorithm, file path, digest and time. Strings are recorded by their length
+only, and password hashes are not recorded. The history lives in $XDG_DATA_HOME/hashctl/history.jsonl
+and keeps the newest ` + fmt.Sprint(history.Limit) + ` entries.
+
+Pass a query to fuzzy-search it. --no-history (or ` + historyEnv + `=off)
+skips recording for a run.`,
+	Example: `  hashctl history
+  hashctl history release.tar
+  hashctl history export --format csv -o hashes.csv
+  hashctl history clear`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHistory,
+}
+
+var historyExportCmd = &cobra.Command{
+	Use:   "export [query]",
+	Short: "Write the history as JSON, CSV or a checksum file",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runHistoryExport,
+}
+
+var historyClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the history",
+	Args:  cobra.NoArgs,
+	RunE:  runHistoryClear,
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&historyFlags.disabled, "no-history", false, "do not record hashes in the history (env "+historyEnv+"=off)")
+
+	f := historyCmd.Flags()
+	f.IntVarP(&historyFlags.limit, "limit", "n", 20, "show at most this many entries (0 for all)")
+	f.BoolVar(&historyFlags.json, "json", false, "print entries as JSON")
+
+	ef := historyExportCmd.Flags()
+	ef.StringVarP(&historyFlags.format, "format", "f", history.FormatJSON, "export format: "+strings.Join(history.Formats, ", "))
+	ef.StringVarP(&historyFlags.output, "output", "o", "", "write to this file instead of stdout")
+
+	historyCmd.AddCommand(historyExportCmd)
+	historyCmd.AddCommand(historyClearCmd)
+}
+
+// historyStore returns the history store, or nil when recording is off
+func historyStore() *history.Store {
+	if historyFlags.disabled || os.Getenv(historyEnv) == "off" {
+		return nil
+	}
+	return history.Open(history.DefaultPath())
+}
+
+// recordHistory adds a history recorder to opts. The returned function
+// saves the recorded entries and must be called once hashing is done.
+func recordHistory(opts *hasher.Options) func() {
+	store := historyStore()
+	if store == nil {
+		return func() {}
+	}
+	rec := store.Recorder()
+	opts.Observer = hasher.Observers(opts.Observer, rec)
+	return func() {
+		if err := rec.Close(); err != nil {
+			fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ failed to save history: "+err.Error()))
+		}
+	}
+}
+
+// loadHistory returns entries newest first, narrowed by an optional query
+func loadHistory(args []string) ([]history.Entry, error) {
+	entries, err := history.Open(history.DefaultPath()).Load()
+	if err != nil || len(args) == 0 {
+		return entries, err
+	}
+
+	items := make([]string, len(entries))
+	for i, e := range entries {
+		items[i] = e.Algorithm + " " + e.Label() + " " + e.Digest
+	}
+	var matched []history.Entry
+	for _, i := range fuzzy.Filter(args[0], items) {
+		matched = append(matched, entries[i])
+	}
+	return matched, nil
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	entries, err := loadHistory(args)
+	if err != nil {
+		return printErr(err)
+	}
+	if historyFlags.limit > 0 && len(entries) > historyFlags.limit {
+		entries = entries[:historyFlags.limit]
+	}
+
+	if historyFlags.json {
+		return history.Export(os.Stdout, entries, history.FormatJSON)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println(tui.MutedStyle.Render("no history"))
+		return nil
+	}
+	for _, e := range entries {
+		fmt.Println(tui.MutedStyle.Render(e.Time.Local().Format("2006-01-02 15:04")+"  ") +
+			tui.LabelStyle.Render(fmt.Sprintf("%-12s", e.Algorithm)) + " " +
+			tui.ValueStyle.Render(e.Label()))
+		fmt.Println("  " + tui.HashStyle.Render(e.Digest))
+	}
+	return nil
+}
+
+func runHistoryExport(cmd *cobra.Command, args []string) error {
+	if !slices.Contains(history.Formats, historyFlags.format) {
+		return printErr(fmt.Errorf("unknown export format: %s", historyFlags.format))
+	}
+	entries, err := loadHistory(args)
+	if err != nil {
+		return printErr(err)
+	}
+
+	if historyFlags.output == "" {
+		if err := history.Export(os.Stdout, entries, historyFlags.format); err != nil {
+			return printErr(err)
+		}
+		return nil
+	}
+
+	f, err := os.Create(historyFlags.output)
+	if err != nil {
+		return printErr(err)
+	}
+	err = history.Export(f, entries, historyFlags.format)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return printErr(err)
+	}
+	fmt.Fprintln(os.Stderr, tui.SuccessStyle.Render(fmt.Sprintf("✓ exported %d entries to %s", len(entries), historyFlags.output)))
+	return nil
+}
+
+func runHistoryClear(cmd *cobra.Command, args []string) error {
+	store := history.Open(history.DefaultPath())
+	if err := store.Clear(); err != nil {
+		return printErr(err)
+	}
+	fmt.Println(tui.SuccessStyle.Render("✓ cleared " + store.Path()))
+	return nil
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var identifyJSON bool
+
+var identifyCmd = &cobra.Command{
+	Use:   "identify [hash]",
+	Short: "Guess which algorithm produced a hash",
+	Long: `Classify a digest by its length, character set, encoding and structured
+prefixes ($2b$, $argon2id$, $6$, sha256:, sha384-, multihashes and CIDs)
+and list the likely algorithms, most likely first. Reads the hash from
+stdin when no argument is given.`,
+	Example: `  hashctl identify 5d41402abc4b2a76b9719d911017c592
+  hashctl identify 'sha384-oqVuAfXRKap7fdgcCY5uykM6+R9GqQ8K/uxy9rx7HNQlGYl1kPzQho1wx4JwY8wC'
+  pbpaste | hashctl identify`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runIdentify,
+}
+
+func init() {
+	identifyCmd.Flags().BoolVar(&identifyJSON, "json", false, "print the classification as JSON")
+}
+
+type identifyCandidate struct {
+	Algorithm  string `json:"algorithm,omitempty"`
+	Name       string `json:"name"`
+	Score      int    `json:"score"`
+	Likelihood string `json:"likelihood"`
+	Reason     string `json:"reason"`
+}
+
+type identifyOutput struct {
+	Input      string              `json:"input"`
+	Encoding   string              `json:"encoding"`
+	Bytes      int                 `json:"bytes,omitempty"`
+	Candidates []identifyCandidate `json:"candidates"`
+}
+
+func runIdentify(cmd *cobra.Command, args []string) error {
+	var input string
+	if len(args) == 1 {
+		input = args[0]
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return printErr(errors.New("no hash given"))
+		}
+		input = line
+	}
+
+	id := hasher.Identify(input)
+	if id.Input == "" {
+		return printErr(errors.New("no hash given"))
+	}
+
+	if identifyJSON {
+		out := identifyOutput{
+			Input:      id.Input,
+			Encoding:   id.Encoding,
+			Bytes:      id.Bytes,
+			Candidates: []identifyCandidate{},
+		}
+		for _, c := range id.Candidates {
+			out.Candidates = append(out.Candidates, identifyCandidate{
+				Algorithm:  c.Algorithm,
+				Name:       c.Name,
+				Score:      c.Score,
+				Likelihood: c.Likelihood(),
+				Reason:     c.Reason,
+			})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			return err
+		}
+	} else {
+		printIdentification(id)
+	}
+
+	if len(id.Candidates) == 0 {
+		return errors.New("no matching algorithm")
+	}
+	return nil
+}
+
+func printIdentification(id hasher.Identification) {
+	label := tui.MutedStyle
+	value := tui.ValueStyle
+
+	encoding := id.Encoding
+	if id.Bytes > 0 {
+		encoding += fmt.Sprintf(" • %d bytes", id.Bytes)
+	}
+
+	fmt.Println()
+	fmt.Println(label.Render("input     ") + value.Render(truncateMiddle(id.Input, 72)))
+	fmt.Println(label.Render("encoding  ") + value.Render(encoding))
+	fmt.Println()
+
+	if len(id.Candidates) == 0 {
+		fmt.Println(tui.ErrorStyle.Render("✗ no matching algorithm"))
+		return
+	}
+	for i, c := range id.Candidates {
+		key := c.Algorithm
+		if key == "" {
+			key = "-"
+		}
+		fmt.Printf("%s %s %s %s %s\n",
+			label.Render(fmt.Sprintf("%2d.", i+1)),
+			tui.LabelStyle.Render(fmt.Sprintf("%-14s", c.Name)),
+			value.Render(fmt.Sprintf("%-12s", key)),
+			likelihoodStyle(c).Render(fmt.Sprintf("%-9s", c.Likelihood())),
+			label.Render(c.Reason))
+	}
+	if activePolicy.Name != hasher.DefaultPolicy {
+		for _, c := range id.Candidates {
+			if c.Algorithm != "" && !activePolicy.Allowed(c.Algorithm) {
+				fmt.Println()
+				fmt.Println(tui.WarningStyle.Render("⚠ some candidates are forbidden under the " + activePolicy.Name + " policy"))
+				break
+			}
+		}
+	}
+}
+
+func likelihoodStyle(c hasher.Candidate) lipgloss.Style {
+	switch {
+	case c.Score >= 70:
+		return tui.SuccessStyle
+	case c.Score >= 40:
+		return tui.WarningStyle
+	default:
+		return tui.DimStyle
+	}
+}
+
+// truncateMiddle shortens long inputs, keeping both ends visible
+func truncateMiddle(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	half := (n - 1) / 2
+	return s[:half] + "…" + s[len(s)-half:]
+}
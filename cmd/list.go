@@ -8,10 +8,18 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var listAll bool
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List available algorithms",
-	Run:   runList,
+	Long: `List the algorithms allowed by the active policy. Deprecated algorithms
+are marked; --all also shows forbidden ones.`,
+	Run: runList,
+}
+
+func init() {
+	listCmd.Flags().BoolVar(&listAll, "all", false, "include algorithms forbidden by the policy")
 }
 
 func runList(cmd *cobra.Command, args []string) {
@@ -23,12 +31,18 @@ func runList(cmd *cobra.Command, args []string) {
 	}
 
 	fmt.Println()
-	fmt.Println(tui.LogoStyle.Render("hashctl") + tui.LogoAccent.Render(" algorithms"))
+	fmt.Println(tui.LogoStyle.Render("hashctl") + tui.LogoAccent.Render(" algorithms ") +
+		tui.MutedStyle.Render("policy "+activePolicy.Name))
 	fmt.Println()
 
 	for _, cat := range categories {
-		algs, ok := byCategory[cat]
-		if !ok || len(algs) == 0 {
+		var algs []hasher.Algorithm
+		for _, alg := range byCategory[cat] {
+			if listAll || activePolicy.Allowed(getKeyForAlg(alg.Name)) {
+				algs = append(algs, alg)
+			}
+		}
+		if len(algs) == 0 {
 			continue
 		}
 
@@ -42,6 +56,13 @@ func runList(cmd *cobra.Command, args []string) {
 			key := getKeyForAlg(alg.Name)
 			name := tui.LabelStyle.Render(fmt.Sprintf("%-14s", key))
 			desc := tui.MutedStyle.Render(alg.Description)
+			switch activePolicy.Status(key) {
+			case hasher.StatusDeprecated:
+				desc = tui.WarningStyle.Render("[deprecated] ") + desc
+			case hasher.StatusForbidden:
+				name = tui.DimStyle.Render(fmt.Sprintf("%-14s", key))
+				desc = tui.ErrorStyle.Render("[forbidden] ") + desc
+			}
 			fmt.Printf("  %s %s\n", name, desc)
 		}
 		fmt.Println()
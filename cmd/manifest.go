@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/manifest"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var manifestFlags struct {
+	algorithm string
+	output    string
+	json      bool
+}
+
+var manifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "Snapshot directories and compare snapshots",
+}
+
+var manifestCreateCmd = &cobra.Command{
+	Use:   "create <dir>",
+	Short: "Write a manifest of every file below a directory",
+	Long: `Recursively hash every regular file below a directory and write a JSON
+manifest with each file's path, size, mode and digest.`,
+	Example: `  hashctl manifest create build/ -o build-1234.json`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runManifestCreate,
+}
+
+var manifestDiffCmd = &cobra.Command{
+	Use:   "diff <a> <b>",
+	Short: "Report added, removed, modified and renamed files",
+	Long: `Compare two manifests and report added, removed, modified and renamed
+files. Renames are files whose digest is unchanged but whose path moved.
+Either side may also be a directory, which is hashed on the fly.
+Exits non-zero when the snapshots differ.`,
+	Example: `  hashctl manifest diff build-1233.json build-1234.json
+  hashctl manifest diff build-1234.json build/`,
+	Args: cobra.ExactArgs(2),
+	RunE: runManifestDiff,
+}
+
+func init() {
+	manifestCmd.PersistentFlags().StringVarP(&manifestFlags.algorithm, "algorithm", "a", hasher.DefaultOptions().Algorithm, "hash algorithm")
+	manifestCreateCmd.Flags().StringVarP(&manifestFlags.output, "output", "o", "", "write the manifest to a file instead of stdout")
+	manifestDiffCmd.Flags().BoolVar(&manifestFlags.json, "json", false, "print changes as JSON")
+
+	manifestCmd.AddCommand(manifestCreateCmd)
+	manifestCmd.AddCommand(manifestDiffCmd)
+}
+
+func runManifestCreate(cmd *cobra.Command, args []string) error {
+	opts, done := hashOptions()
+	defer done()
+	opts.Algorithm = manifestFlags.algorithm
+	if err := checkAlgorithm(opts.Algorithm); err != nil {
+		return printErr(err)
+	}
+
+	m, err := manifest.Create(args[0], opts)
+	if m == nil {
+		return printErr(err)
+	}
+	if err != nil {
+		printErr(err)
+	}
+
+	out := os.Stdout
+	if manifestFlags.output != "" {
+		f, err := os.Create(manifestFlags.output)
+		if err != nil {
+			return printErr(err)
+		}
+		defer f.Close()
+		out = f
+	}
+	if werr := m.Write(out); werr != nil {
+		return printErr(werr)
+	}
+	return err
+}
+
+// loadSnapshot reads a manifest file, or hashes a directory on the fly.
+// Either way its algorithm must pass the policy.
+func loadSnapshot(path, algorithm string) (*manifest.Manifest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		m, err := manifest.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkAlgorithm(m.Algorithm); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return m, nil
+	}
+	if err := checkAlgorithm(algorithm); err != nil {
+		return nil, err
+	}
+	opts, done := hashOptions()
+	defer done()
+	opts.Algorithm = algorithm
+	return manifest.Create(path, opts)
+}
+
+func runManifestDiff(cmd *cobra.Command, args []string) error {
+	a, err := loadSnapshot(args[0], manifestFlags.algorithm)
+	if err != nil {
+		return printErr(err)
+	}
+	// A directory on the right is hashed with the left manifest's algorithm
+	b, err := loadSnapshot(args[1], a.Algorithm)
+	if err != nil {
+		return printErr(err)
+	}
+
+	changes, err := manifest.Diff(a, b)
+	if err != nil {
+		return printErr(err)
+	}
+
+	if manifestFlags.json {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if changes == nil {
+			changes = []manifest.Change{}
+		}
+		if err := enc.Encode(changes); err != nil {
+			return err
+		}
+	} else {
+		printChanges(changes)
+	}
+
+	if len(changes) > 0 {
+		return fmt.Errorf("%d changes", len(changes))
+	}
+	return nil
+}
+
+func printChanges(changes []manifest.Change) {
+	if len(changes) == 0 {
+		fmt.Println(tui.SuccessStyle.Render("✓ no changes"))
+		return
+	}
+
+	counts := make(map[manifest.ChangeKind]int)
+	for _, c := range changes {
+		counts[c.Kind]++
+		switch c.Kind {
+		case manifest.Added:
+			fmt.Println(tui.SuccessStyle.Render("+ ") + tui.ValueStyle.Render(c.Path))
+		case manifest.Removed:
+			fmt.Println(tui.ErrorStyle.Render("- ") + tui.ValueStyle.Render(c.Path))
+		case manifest.Modified:
+			fmt.Println(tui.WarningStyle.Render("~ ") + tui.ValueStyle.Render(c.Path))
+		case manifest.Renamed:
+			fmt.Println(tui.LabelStyle.Render("→ ") + tui.MutedStyle.Render(c.OldPath+" → ") + tui.ValueStyle.Render(c.Path))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("%d added, %d removed, %d modified, %d renamed",
+		counts[manifest.Added], counts[manifest.Removed], counts[manifest.Modified], counts[manifest.Renamed])))
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/atharvamhaske/hashctl/internal/oci"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var ociJSON bool
+
+var ociCmd = &cobra.Command{
+	Use:   "oci",
+	Short: "Verify OCI and Docker image content digests",
+}
+
+var ociVerifyCmd = &cobra.Command{
+	Use:   "verify <layout-dir|image.tar>",
+	Short: "Recompute and check every blob digest of an image",
+	Long: `Recompute the sha256/sha512 digest of every blob in an OCI image layout
+directory or a 'docker save' tarball (optionally gzip-compressed), then
+check index.json and manifest references for missing blobs, size and
+digest mismatches. Exits non-zero when any problem is found.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOCIVerify,
+}
+
+func init() {
+	ociVerifyCmd.Flags().BoolVar(&ociJSON, "json", false, "print the report as JSON")
+	ociCmd.AddCommand(ociVerifyCmd)
+}
+
+func runOCIVerify(cmd *cobra.Command, args []string) error {
+	opts, done := hashOptions()
+	defer done()
+
+	report, err := oci.Verify(args[0], opts)
+	if err != nil {
+		return printErr(err)
+	}
+
+	if ociJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printOCIReport(report)
+	}
+
+	if !report.OK() {
+		return fmt.Errorf("%d problems found", len(report.Findings))
+	}
+	return nil
+}
+
+func printOCIReport(report oci.Report) {
+	label := tui.MutedStyle
+	value := tui.ValueStyle
+
+	fmt.Println()
+	fmt.Println(label.Render("source     ") + value.Render(report.Source))
+	fmt.Println(label.Render("format     ") + value.Render(report.Format))
+	fmt.Println(label.Render("blobs      ") + value.Render(fmt.Sprintf("%d", report.Blobs)))
+	fmt.Println(label.Render("references ") + value.Render(fmt.Sprintf("%d", report.References)))
+	fmt.Println()
+
+	if report.OK() {
+		fmt.Println(tui.SuccessStyle.Render("✓ all digests verified"))
+		fmt.Println()
+		return
+	}
+
+	for _, f := range report.Findings {
+		fmt.Println(tui.ErrorStyle.Render("✗ " + string(f.Problem) + ": " + f.Path))
+		if f.Expected != "" {
+			fmt.Println(label.Render("  expected ") + value.Render(f.Expected))
+		}
+		if f.Actual != "" {
+			fmt.Println(label.Render("  actual   ") + value.Render(f.Actual))
+		}
+	}
+	fmt.Println()
+}
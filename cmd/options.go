@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/atharvamhaske/hashctl/internal/cache"
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+)
+
+// cacheEnv enables the digest cache without flags, e.g. HASHCTL_CACHE=file
+const cacheEnv = "HASHCTL_CACHE"
+
+var cacheFlags struct {
+	backend  string
+	disabled bool
+}
+
+func init() {
+	f := rootCmd.PersistentFlags()
+	f.StringVar(&cacheFlags.backend, "cache", "", "reuse digests of unchanged files: file, xattr or both (env "+cacheEnv+")")
+	f.Lookup("cache").NoOptDefVal = cache.BackendFile
+	f.BoolVar(&cacheFlags.disabled, "no-cache", false, "disable the digest cache")
+}
+
+// cacheBackend returns the selected cache backend, or "" when disabled
+func cacheBackend() string {
+	if cacheFlags.disabled {
+		return ""
+	}
+	if cacheFlags.backend != "" {
+		return cacheFlags.backend
+	}
+	return os.Getenv(cacheEnv)
+}
+
+// warnCorruptCache reports a digest cache file that is replaced because it
+// could not be read
+func warnCorruptCache(err error) {
+	fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ "+err.Error()+"; starting with an empty cache"))
+}
+
+// hashOptions returns the default hashing options, adjusted by the settings,
+// with the digest cache and telemetry attached when enabled. The returned
+// function saves the cache and must be called once hashing is done.
+func hashOptions() (hasher.Options, func()) {
+	opts := hasher.DefaultOptions()
+	settings.Apply(&opts)
+	opts.Observer = observer()
+
+	backend := cacheBackend()
+	if backend == "" {
+		return opts, func() {}
+	}
+
+	c, err := cache.New(backend)
+	if errors.Is(err, cache.ErrCorrupt) {
+		warnCorruptCache(err)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ cache disabled: "+err.Error()))
+		return opts, func() {}
+	}
+	opts.Cache = c
+	return opts, func() {
+		if err := c.Close(); err != nil {
+			fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ failed to save cache: "+err.Error()))
+		}
+	}
+}
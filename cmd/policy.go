@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+)
+
+// policyEnv selects the algorithm policy without flags, e.g. HASHCTL_POLICY=fips
+const policyEnv = "HASHCTL_POLICY"
+
+var policyFlag string
+
+// activePolicy is resolved before any command runs
+var activePolicy = hasher.Policies[hasher.DefaultPolicy]
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&policyFlag, "policy", "",
+		"algorithm policy: "+strings.Join(hasher.PolicyNames(), ", ")+" (env "+policyEnv+", default "+hasher.DefaultPolicy+")")
+}
+
+// setupPolicy resolves the policy from the flag or environment
+func setupPolicy() error {
+	name := policyFlag
+	if name == "" {
+		name = os.Getenv(policyEnv)
+	}
+	if name == "" {
+		name = hasher.DefaultPolicy
+	}
+
+	p, ok := hasher.GetPolicy(name)
+	if !ok {
+		return fmt.Errorf("unknown policy %q (use %s)", name, strings.Join(hasher.PolicyNames(), ", "))
+	}
+	activePolicy = p
+	return nil
+}
+
+// deprecationWarned holds the algorithms already warned about, so a command
+// that checks an algorithm more than once warns once
+var deprecationWarned = make(map[string]bool)
+
+// checkAlgorithm refuses unknown and forbidden algorithms and warns on
+// stderr about deprecated ones
+func checkAlgorithm(algorithm string) error {
+	if _, ok := hasher.GetAlgorithm(algorithm); !ok {
+		return fmt.Errorf("unknown algorithm: %s", algorithm)
+	}
+	if err := activePolicy.Check(algorithm); err != nil {
+		return err
+	}
+	if activePolicy.Status(algorithm) == hasher.StatusDeprecated && !deprecationWarned[algorithm] {
+		deprecationWarned[algorithm] = true
+		fmt.Fprintln(os.Stderr, tui.WarningStyle.Render(
+			fmt.Sprintf("⚠ %s is deprecated under the %s policy", algorithm, activePolicy.Name)))
+	}
+	return nil
+}
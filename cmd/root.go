@@ -18,8 +18,27 @@ Supported algorithms include SHA-256, SHA-512, BLAKE2, SHA-3, MD5,
 bcrypt, Argon2id, and more.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := setupConfig(); err != nil {
+			return printErr(err)
+		}
+		if err := setupPolicy(); err != nil {
+			return printErr(err)
+		}
+		if err := setupTelemetry(); err != nil {
+			return printErr(err)
+		}
+		if err := applySettings(cmd); err != nil {
+			return printErr(err)
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return tui.Run()
+		return tui.Run(activePolicy, historyStore(), tui.Settings{
+			Path: configPath,
+			File: fileSettings,
+			Env:  envSettings,
+		})
 	},
 }
 
@@ -29,7 +48,26 @@ func Execute() error {
 }
 
 func init() {
+	// Errors are silenced so commands can style their own; flag errors
+	// would otherwise exit without a word
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return printErr(err)
+	})
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(hashCmd)
+	rootCmd.AddCommand(decodeCmd)
+	rootCmd.AddCommand(gitCmd)
+	rootCmd.AddCommand(ociCmd)
+	rootCmd.AddCommand(manifestCmd)
+	rootCmd.AddCommand(dupesCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(selftestCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(identifyCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(configCmd)
 }
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var selftestJSON bool
+
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Check every algorithm against known-answer test vectors",
+	Long: `Run NIST, RFC and reference-implementation test vectors for every
+algorithm: the empty string, "abc" and one million 'a' characters for
+digests, plus bcrypt and argon2id reference hashes. Exits non-zero if any
+algorithm fails.`,
+	Args: cobra.NoArgs,
+	RunE: runSelftest,
+}
+
+func init() {
+	selftestCmd.Flags().BoolVar(&selftestJSON, "json", false, "print results as JSON")
+}
+
+type selftestResult struct {
+	Algorithm string   `json:"algorithm"`
+	Passed    int      `json:"passed"`
+	Failures  []string `json:"failures,omitempty"`
+	OK        bool     `json:"ok"`
+}
+
+func runSelftest(cmd *cobra.Command, args []string) error {
+	results := hasher.SelfTest()
+
+	failed := 0
+	for _, r := range results {
+		if !r.OK() {
+			failed++
+		}
+	}
+
+	if selftestJSON {
+		out := make([]selftestResult, 0, len(results))
+		for _, r := range results {
+			out = append(out, selftestResult{
+				Algorithm: r.Algorithm,
+				Passed:    r.Passed,
+				Failures:  r.Failures,
+				OK:        r.OK(),
+			})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			return err
+		}
+	} else {
+		printSelftest(results, failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d algorithms failed", failed)
+	}
+	return nil
+}
+
+func printSelftest(results []hasher.SelfTestResult, failed int) {
+	fmt.Println()
+	fmt.Println(tui.LogoStyle.Render("hashctl") + tui.LogoAccent.Render(" selftest ") +
+		tui.MutedStyle.Render(runtime.GOOS+"/"+runtime.GOARCH))
+	fmt.Println()
+
+	for _, r := range results {
+		name := fmt.Sprintf("%-14s", r.Algorithm)
+		if r.OK() {
+			fmt.Println(tui.SuccessStyle.Render("✓ "+name) + tui.MutedStyle.Render(fmt.Sprintf("%d vectors", r.Passed)))
+			continue
+		}
+		fmt.Println(tui.ErrorStyle.Render("✗ " + name))
+		for _, f := range r.Failures {
+			fmt.Println(tui.MutedStyle.Render("    " + f))
+		}
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("✗ %d of %d algorithms failed", failed, len(results))))
+	} else {
+		fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("✓ all %d algorithms passed", len(results))))
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/atharvamhaske/hashctl/internal/metrics"
+	"github.com/atharvamhaske/hashctl/internal/server"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var serveFlags struct {
+	addr        string
+	root        string
+	maxBody     int64
+	parallelism int
+	metrics     bool
+	maxCost     int
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a local HTTP hashing service",
+	Long: `Run a local HTTP API for hashing.
+
+Endpoints:
+  GET  /v1/algorithms                 list available algorithms
+  POST /v1/hash?algorithm=sha256      hash the request body (streamed)
+  GET  /v1/hash/path?path=rel/file    hash a file below --root
+  POST /v1/verify                     {"algorithm","password","hash"} → {"match"}
+  GET  /healthz                       liveness check
+  GET  /metrics                       Prometheus metrics (with --metrics)
+
+Use --addr unix:/path/to.sock to listen on a Unix socket. At most
+--parallel requests hash at once; SIGINT/SIGTERM shut down gracefully.
+/v1/verify refuses bcrypt hashes above --max-bcrypt-cost.`,
+	Example: `  hashctl serve --addr 127.0.0.1:8080 --root /srv/artifacts
+  curl --data-binary @file.iso 'localhost:8080/v1/hash?algorithm=sha512'`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	cfg := server.DefaultConfig()
+	f := serveCmd.Flags()
+	f.StringVar(&serveFlags.addr, "addr", "127.0.0.1:8080", "TCP address or unix:/path socket to listen on")
+	f.StringVar(&serveFlags.root, "root", "", "directory server-side paths may be hashed from (disabled when empty)")
+	f.Int64Var(&serveFlags.maxBody, "max-body", cfg.MaxBodySize, "maximum upload size in bytes")
+	f.IntVarP(&serveFlags.parallelism, "parallel", "p", cfg.Options.Parallelism, "maximum concurrent hashing requests")
+	f.BoolVar(&serveFlags.metrics, "metrics", false, "expose Prometheus metrics at /metrics")
+	f.IntVar(&serveFlags.maxCost, "max-bcrypt-cost", 0, "highest bcrypt cost /v1/verify accepts (default the bcrypt_cost setting)")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if serveFlags.metrics && metricsRegistry == nil {
+		metricsRegistry = metrics.New()
+	}
+	opts, done := hashOptions()
+	defer done()
+	if serveFlags.parallelism > 0 {
+		opts.Parallelism = serveFlags.parallelism
+	}
+
+	cfg := server.DefaultConfig()
+	cfg.Options = opts
+	cfg.Root = serveFlags.root
+	cfg.MaxBodySize = serveFlags.maxBody
+	cfg.Policy = &activePolicy
+	cfg.MaxBcryptCost = serveFlags.maxCost
+	if serveFlags.metrics {
+		cfg.Metrics = metricsRegistry.Handler()
+	}
+
+	if err := checkAlgorithm(opts.Algorithm); err != nil {
+		return printErr(err)
+	}
+	srv, err := server.New(cfg)
+	if err != nil {
+		return printErr(err)
+	}
+	ln, err := server.Listen(serveFlags.addr)
+	if err != nil {
+		return printErr(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("listening on %s (%d workers)", ln.Addr(), opts.Parallelism)))
+	if err := srv.Serve(ctx, ln); err != nil {
+		return printErr(err)
+	}
+	fmt.Println(tui.MutedStyle.Render("shut down"))
+	return nil
+}
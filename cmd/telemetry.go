@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/logging"
+	"github.com/atharvamhaske/hashctl/internal/metrics"
+	"github.com/atharvamhaske/hashctl/internal/server"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+)
+
+var telemetryFlags struct {
+	logJSON     bool
+	logLevel    string
+	metricsAddr string
+}
+
+// Telemetry shared by every command; both are nil when disabled
+var (
+	resultLogger    hasher.Observer
+	metricsRegistry *metrics.Registry
+)
+
+func init() {
+	f := rootCmd.PersistentFlags()
+	f.BoolVar(&telemetryFlags.logJSON, "log-json", false, "log every hash result as JSON to stderr")
+	f.StringVar(&telemetryFlags.logLevel, "log-level", "info", "minimum log level: debug, info, warn or error")
+	f.StringVar(&telemetryFlags.metricsAddr, "metrics-addr", "", "serve Prometheus metrics on this address while the command runs")
+}
+
+// setupTelemetry validates the logging flags and starts the metrics endpoint
+func setupTelemetry() error {
+	level, err := logging.ParseLevel(telemetryFlags.logLevel)
+	if err != nil {
+		return err
+	}
+	if telemetryFlags.logJSON {
+		resultLogger = logging.ResultLogger{Logger: logging.New(os.Stderr, level)}
+	}
+
+	if telemetryFlags.metricsAddr == "" {
+		return nil
+	}
+	metricsRegistry = metrics.New()
+	ln, err := server.Listen(telemetryFlags.metricsAddr)
+	if err != nil {
+		return fmt.Errorf("metrics endpoint: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metricsRegistry.Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ metrics endpoint: "+err.Error()))
+		}
+	}()
+	return nil
+}
+
+// observer returns the active result observers, or nil when telemetry is off
+func observer() hasher.Observer {
+	var reg hasher.Observer
+	if metricsRegistry != nil {
+		reg = metricsRegistry
+	}
+	return hasher.Observers(resultLogger, reg)
+}
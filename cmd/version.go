@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"runtime"
 
+	"github.com/atharvamhaske/hashctl/internal/config"
 	"github.com/atharvamhaske/hashctl/internal/tui"
 	"github.com/atharvamhaske/hashctl/internal/version"
 	"github.com/spf13/cobra"
@@ -14,10 +15,18 @@ var (
 	BuildDate = "2026-01-31"
 )
 
+var versionFlags struct {
+	noUpdateCheck bool
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version and check for updates",
-	Run:   runVersion,
+	Long: `Print version information and check GitHub for a newer release.
+
+The check is skipped with --no-update-check, HASHCTL_UPDATE_CHECK=off or
+update_check set to off in the settings.`,
+	Run: runVersion,
 }
 
 var checkCmd = &cobra.Command{
@@ -27,6 +36,10 @@ var checkCmd = &cobra.Command{
 	Run:   runCheck,
 }
 
+func init() {
+	versionCmd.Flags().BoolVar(&versionFlags.noUpdateCheck, "no-update-check", false, "do not check GitHub for a newer release")
+}
+
 func runVersion(cmd *cobra.Command, args []string) {
 	fmt.Println()
 	fmt.Println(tui.LogoStyle.Render("hashctl") + tui.LogoAccent.Render(" ⟡"))
@@ -39,8 +52,13 @@ func runVersion(cmd *cobra.Command, args []string) {
 	fmt.Println(label.Render("built     ") + value.Render(BuildDate))
 	fmt.Println(label.Render("go        ") + value.Render(runtime.Version()))
 	fmt.Println(label.Render("platform  ") + value.Render(runtime.GOOS+"/"+runtime.GOARCH))
+	fmt.Println(label.Render("policy    ") + value.Render(activePolicy.Name))
 	fmt.Println()
 
+	if versionFlags.noUpdateCheck || settings.UpdateCheck == config.UpdateCheckOff {
+		return
+	}
+
 	// Check for updates
 	latest, err := version.CheckLatestVersion(Version)
 	if err == nil && version.IsUpdateAvailable(Version, latest.TagName) {
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/tui"
+	"github.com/atharvamhaske/hashctl/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+var watchFlags struct {
+	algorithm   string
+	debounce    time.Duration
+	logFile     string
+	interactive bool
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <paths...>",
+	Short: "Re-hash files whenever they change",
+	Long: `Watch files and directories (recursively) with Linux inotify and re-hash
+changed files once they have been quiet for the debounce interval. Every
+digest change is printed as old → new, and optionally appended to a log.`,
+	Example: `  hashctl watch dist/ --log digests.log
+  hashctl watch build/app.bin --tui`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runWatch,
+}
+
+func init() {
+	f := watchCmd.Flags()
+	f.StringVarP(&watchFlags.algorithm, "algorithm", "a", hasher.DefaultOptions().Algorithm, "hash algorithm")
+	f.DurationVar(&watchFlags.debounce, "debounce", watch.DefaultDebounce, "quiet period before a changed file is re-hashed")
+	f.StringVar(&watchFlags.logFile, "log", "", "append changes to this file")
+	f.BoolVar(&watchFlags.interactive, "tui", false, "show a live-updating screen")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	opts, done := hashOptions()
+	defer done()
+	opts.Algorithm = watchFlags.algorithm
+	if err := checkAlgorithm(opts.Algorithm); err != nil {
+		return printErr(err)
+	}
+
+	w, err := watch.New(args, opts, watchFlags.debounce)
+	if err != nil {
+		return printErr(err)
+	}
+	defer w.Close()
+
+	if watchFlags.interactive {
+		return tui.RunWatch(w, opts.Algorithm)
+	}
+
+	var logFile *os.File
+	if watchFlags.logFile != "" {
+		logFile, err = os.OpenFile(watchFlags.logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return printErr(err)
+		}
+		defer logFile.Close()
+	}
+
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("watching %d files with %s (ctrl+c to stop)", len(w.Paths()), opts.Algorithm)))
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	for {
+		select {
+		case <-interrupt:
+			return nil
+		case e, ok := <-w.Events():
+			if !ok {
+				return nil
+			}
+			fmt.Println(tui.DimStyle.Render(e.Time.Format("15:04:05")) + " " + tui.WatchEventLine(e))
+			if logFile != nil {
+				fmt.Fprintln(logFile, watchLogLine(e))
+			}
+		}
+	}
+}
+
+// watchLogLine formats an event for the plain-text log
+func watchLogLine(e watch.Event) string {
+	ts := e.Time.Format(time.RFC3339)
+	switch {
+	case e.Path == "":
+		return fmt.Sprintf("%s warning %v", ts, e.Error)
+	case e.Error != nil:
+		return fmt.Sprintf("%s error %s %v", ts, e.Path, e.Error)
+	case e.Old == "":
+		return fmt.Sprintf("%s added %s - %s", ts, e.Path, e.New)
+	case e.New == "":
+		return fmt.Sprintf("%s removed %s %s -", ts, e.Path, e.Old)
+	default:
+		return fmt.Sprintf("%s modified %s %s %s", ts, e.Path, e.Old, e.New)
+	}
+}
@@ -0,0 +1,209 @@
+// Package bench measures in-memory hashing throughput
+package bench
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+)
+
+// DefaultSizes are the message sizes measured by default, 64 B to 1 GiB
+var DefaultSizes = []int64{64, 1 << 10, 64 << 10, 1 << 20, 64 << 20, 1 << 30}
+
+// DefaultDuration is the minimum time spent on each measurement
+const DefaultDuration = 250 * time.Millisecond
+
+// chunkSize bounds the buffer messages are built from, so large sizes do
+// not need a buffer of their own
+const chunkSize = 1 << 20
+
+// Config selects what to measure
+type Config struct {
+	// Algorithms are registry keys; password hashes are rejected
+	Algorithms []string
+	Sizes      []int64
+	// Parallelism is the worker count of the parallel pass; 1 skips it
+	Parallelism int
+	// Duration is the minimum time per measurement; at least one message
+	// is always hashed
+	Duration time.Duration
+}
+
+// DefaultConfig measures every non-password algorithm at DefaultSizes
+func DefaultConfig() Config {
+	var algs []string
+	for _, name := range hasher.ListNames() {
+		if !hasher.Registry[name].IsPasswordHash {
+			algs = append(algs, name)
+		}
+	}
+	return Config{
+		Algorithms:  algs,
+		Sizes:       DefaultSizes,
+		Parallelism: runtime.NumCPU(),
+		Duration:    DefaultDuration,
+	}
+}
+
+// Measurement is the throughput of one algorithm at one message size
+type Measurement struct {
+	Algorithm string        `json:"algorithm"`
+	Size      int64         `json:"size"`
+	Workers   int           `json:"workers"`
+	Messages  int64         `json:"messages"`
+	Duration  time.Duration `json:"duration_ns"`
+	// MBps is throughput in decimal megabytes (10^6 bytes) per second
+	MBps float64 `json:"mb_per_s"`
+}
+
+// Runs returns how many measurements cfg produces
+func (cfg Config) Runs() int {
+	n := len(cfg.Algorithms) * len(cfg.Sizes)
+	if cfg.Parallelism > 1 {
+		n *= 2
+	}
+	return n
+}
+
+// Run measures every algorithm at every size, first on a single core and
+// then with cfg.Parallelism workers. Sizes are the outer loop so results
+// for small messages arrive first. onResult, if set, sees each measurement
+// as soon as it is taken.
+func Run(cfg Config, onResult func(Measurement)) ([]Measurement, error) {
+	algs := make([]hasher.Algorithm, len(cfg.Algorithms))
+	for i, name := range cfg.Algorithms {
+		alg, ok := hasher.GetAlgorithm(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown algorithm: %s", name)
+		}
+		if alg.IsPasswordHash {
+			return nil, fmt.Errorf("%s is a password hash; throughput is not meaningful", name)
+		}
+		algs[i] = alg
+	}
+	for _, size := range cfg.Sizes {
+		if size <= 0 {
+			return nil, fmt.Errorf("invalid message size: %d", size)
+		}
+	}
+
+	// Random data keeps checksums from taking shortcuts on zeroes
+	buf := make([]byte, chunkSize)
+	rand.New(rand.NewSource(1)).Read(buf)
+
+	workers := []int{1}
+	if cfg.Parallelism > 1 {
+		workers = append(workers, cfg.Parallelism)
+	}
+
+	var results []Measurement
+	for _, size := range cfg.Sizes {
+		for i, alg := range algs {
+			for _, w := range workers {
+				n, d := measure(alg, size, w, cfg.Duration, buf)
+				m := Measurement{
+					Algorithm: cfg.Algorithms[i],
+					Size:      size,
+					Workers:   w,
+					Messages:  n,
+					Duration:  d,
+					MBps:      float64(n*size) / d.Seconds() / 1e6,
+				}
+				results = append(results, m)
+				if onResult != nil {
+					onResult(m)
+				}
+			}
+		}
+	}
+	return results, nil
+}
+
+// measure hashes size-byte messages on each worker until the minimum
+// duration has passed and returns the message count and wall time
+func measure(alg hasher.Algorithm, size int64, workers int, minDuration time.Duration, buf []byte) (int64, time.Duration) {
+	// Check the clock about once per chunk of data rather than per message
+	batch := int64(1)
+	if size < chunkSize {
+		batch = chunkSize / size
+	}
+
+	var total int64
+	var wg sync.WaitGroup
+	start := time.Now()
+	deadline := start.Add(minDuration)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h := alg.NewHash()
+			sum := make([]byte, 0, h.Size())
+			var n int64
+			for {
+				for i := int64(0); i < batch; i++ {
+					h.Reset()
+					for left := size; left > 0; {
+						k := min(left, int64(len(buf)))
+						h.Write(buf[:k])
+						left -= k
+					}
+					sum = h.Sum(sum[:0])
+				}
+				n += batch
+				if !time.Now().Before(deadline) {
+					break
+				}
+			}
+			atomic.AddInt64(&total, n)
+		}()
+	}
+	wg.Wait()
+	return total, time.Since(start)
+}
+
+// ParseSize parses sizes like "64", "4K", "64KiB", "1M" or "1G" using
+// binary units
+func ParseSize(s string) (int64, error) {
+	t := strings.ToUpper(strings.TrimSpace(s))
+	t = strings.TrimSuffix(strings.TrimSuffix(t, "B"), "I")
+
+	mult := int64(1)
+	if t != "" {
+		switch t[len(t)-1] {
+		case 'K':
+			mult = 1 << 10
+		case 'M':
+			mult = 1 << 20
+		case 'G':
+			mult = 1 << 30
+		}
+		if mult > 1 {
+			t = t[:len(t)-1]
+		}
+	}
+
+	n, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * mult, nil
+}
+
+// FormatSize renders a message size compactly, e.g. "64 B" or "1 GiB"
+func FormatSize(n int64) string {
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	i := 0
+	for i < len(units)-1 && n >= 1024 && n%1024 == 0 {
+		n /= 1024
+		i++
+	}
+	return fmt.Sprintf("%d %s", n, units[i])
+}
@@ -0,0 +1,389 @@
+// Package cache persists file digests between runs so unchanged files are
+// not re-read
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Backend names accepted by New
+const (
+	BackendFile  = "file"
+	BackendXattr = "xattr"
+	BackendBoth  = "both"
+)
+
+// fileVersion is the on-disk format version of the file store
+const fileVersion = 1
+
+// state is the file metadata a cached digest is valid for. Any change to
+// size, modification time, change time or inode invalidates the digest.
+type state struct {
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mtime"`
+	CTime   int64  `json:"ctime"`
+	Inode   uint64 `json:"ino"`
+	Dev     uint64 `json:"dev"`
+}
+
+func stateOf(info os.FileInfo) state {
+	ctime, ino, dev := sysStat(info)
+	return state{
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+		CTime:   ctime,
+		Inode:   ino,
+		Dev:     dev,
+	}
+}
+
+// Cache is a digest cache that can be saved and inspected
+type Cache interface {
+	Lookup(filename string, info os.FileInfo, algorithm string) (string, bool)
+	Store(filename string, info os.FileInfo, algorithm, digest string)
+	Close() error
+}
+
+// New opens a cache backend. The file store lives under the user cache
+// directory ($XDG_CACHE_HOME/hashctl on Linux). When it is corrupt, the
+// cache is returned empty together with an error wrapping ErrCorrupt.
+func New(backend string) (Cache, error) {
+	switch backend {
+	case BackendFile:
+		f, err := OpenFile(DefaultPath())
+		if err != nil && !errors.Is(err, ErrCorrupt) {
+			return nil, err
+		}
+		return f, err
+	case BackendXattr:
+		return NewXattr()
+	case BackendBoth:
+		x, err := NewXattr()
+		if err != nil {
+			return nil, err
+		}
+		f, err := OpenFile(DefaultPath())
+		if err != nil && !errors.Is(err, ErrCorrupt) {
+			return nil, err
+		}
+		return &both{xattr: x, file: f}, err
+	default:
+		return nil, fmt.Errorf("unknown cache backend: %s (use %s, %s or %s)", backend, BackendFile, BackendXattr, BackendBoth)
+	}
+}
+
+// DefaultPath returns the location of the file store
+func DefaultPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "hashctl", "digests.json")
+}
+
+type record struct {
+	Path      string `json:"path"`
+	Algorithm string `json:"algorithm"`
+	state
+	Digest string `json:"digest"`
+	Used   int64  `json:"used"` // unix seconds of the last hit or store
+}
+
+type fileData struct {
+	Version int      `json:"version"`
+	Entries []record `json:"entries"`
+}
+
+// ErrCorrupt means the file store could not be parsed
+var ErrCorrupt = errors.New("digest cache is corrupt")
+
+// FileStore keeps digests in a single JSON file, keyed by absolute path
+// and algorithm. Saving merges with entries other processes saved in the
+// meantime, under a lock on a sibling .lock file.
+type FileStore struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]*record
+	dirty   bool
+}
+
+// OpenFile loads the file store at path, starting empty if it does not
+// exist. A store that cannot be parsed is returned empty, to be replaced
+// on the next save, together with an error wrapping ErrCorrupt.
+func OpenFile(path string) (*FileStore, error) {
+	s := &FileStore{path: path}
+
+	entries, err := readEntries(path)
+	if err != nil && !errors.Is(err, ErrCorrupt) {
+		return nil, err
+	}
+	s.entries = entries
+	s.dirty = err != nil
+	return s, err
+}
+
+// readEntries loads the records saved at path; a missing file or one in
+// an unknown format holds none
+func readEntries(path string) (map[string]*record, error) {
+	entries := make(map[string]*record)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fd fileData
+	if err := json.Unmarshal(data, &fd); err != nil {
+		return entries, fmt.Errorf("%s: %w: %v", path, ErrCorrupt, err)
+	}
+	if fd.Version != fileVersion {
+		// Unknown format, start over rather than misread it
+		return entries, nil
+	}
+	for i := range fd.Entries {
+		r := fd.Entries[i]
+		entries[key(r.Path, r.Algorithm)] = &r
+	}
+	return entries, nil
+}
+
+func key(path, algorithm string) string {
+	return algorithm + "\x00" + path
+}
+
+func absPath(filename string) string {
+	if abs, err := filepath.Abs(filename); err == nil {
+		return abs
+	}
+	return filename
+}
+
+// Lookup returns the cached digest if the file is unchanged
+func (s *FileStore) Lookup(filename string, info os.FileInfo, algorithm string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.entries[key(absPath(filename), algorithm)]
+	if !ok || r.state != stateOf(info) {
+		return "", false
+	}
+	r.Used = time.Now().Unix()
+	s.dirty = true
+	return r.Digest, true
+}
+
+// known reports whether the store has a record for the file, valid or not
+func (s *FileStore) known(filename, algorithm string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.entries[key(absPath(filename), algorithm)]
+	return ok
+}
+
+// Store records a digest for the file's current metadata
+func (s *FileStore) Store(filename string, info os.FileInfo, algorithm, digest string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := absPath(filename)
+	s.entries[key(path, algorithm)] = &record{
+		Path:      path,
+		Algorithm: algorithm,
+		state:     stateOf(info),
+		Digest:    digest,
+		Used:      time.Now().Unix(),
+	}
+	s.dirty = true
+}
+
+// Close saves the store if it changed
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s.merge()
+	return s.save()
+}
+
+// lock serialises saving between processes
+func (s *FileStore) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := flock(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() { f.Close() }, nil
+}
+
+// merge adds the entries saved since the store was opened, keeping the
+// most recently used record of each file; the caller holds s.mu and the
+// lock
+func (s *FileStore) merge() {
+	// A corrupt file has nothing to keep and is replaced
+	saved, _ := readEntries(s.path)
+	for k, r := range saved {
+		if cur, ok := s.entries[k]; !ok || r.Used > cur.Used {
+			s.entries[k] = r
+		}
+	}
+}
+
+// save atomically replaces the store file; the caller holds s.mu and the
+// lock
+func (s *FileStore) save() error {
+	fd := fileData{Version: fileVersion, Entries: make([]record, 0, len(s.entries))}
+	for _, r := range s.entries {
+		fd.Entries = append(fd.Entries, *r)
+	}
+	sort.Slice(fd.Entries, func(i, j int) bool {
+		if fd.Entries[i].Path != fd.Entries[j].Path {
+			return fd.Entries[i].Path < fd.Entries[j].Path
+		}
+		return fd.Entries[i].Algorithm < fd.Entries[j].Algorithm
+	})
+
+	data, err := json.Marshal(fd)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".digests-*.json")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// Stats describes the contents of a file store
+type Stats struct {
+	Path        string         `json:"path"`
+	Bytes       int64          `json:"bytes"`
+	Entries     int            `json:"entries"`
+	Stale       int            `json:"stale"` // files missing or changed since caching
+	ByAlgorithm map[string]int `json:"by_algorithm"`
+}
+
+// Stats inspects every entry against the file system
+func (s *FileStore) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := Stats{
+		Path:        s.path,
+		Entries:     len(s.entries),
+		ByAlgorithm: make(map[string]int),
+	}
+	if info, err := os.Stat(s.path); err == nil {
+		st.Bytes = info.Size()
+	}
+	for _, r := range s.entries {
+		st.ByAlgorithm[r.Algorithm]++
+		if stale(r) {
+			st.Stale++
+		}
+	}
+	return st
+}
+
+func stale(r *record) bool {
+	info, err := os.Stat(r.Path)
+	return err != nil || stateOf(info) != r.state
+}
+
+// Prune drops entries for missing or changed files, and entries not used
+// within olderThan when it is positive. The store is saved immediately.
+func (s *FileStore) Prune(olderThan time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := s.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	s.merge()
+
+	cutoff := time.Now().Add(-olderThan).Unix()
+	removed := 0
+	for k, r := range s.entries {
+		if stale(r) || (olderThan > 0 && r.Used < cutoff) {
+			delete(s.entries, k)
+			removed++
+		}
+	}
+	if removed == 0 && !s.dirty {
+		return 0, nil
+	}
+	return removed, s.save()
+}
+
+// both stores digests in extended attributes and the file store. Writing
+// an xattr bumps the file's change time, so the file store records the
+// metadata observed afterwards and is the one that can check it.
+type both struct {
+	xattr Cache
+	file  *FileStore
+}
+
+// Lookup trusts the file store for every file it has a record of, so a
+// changed ctime invalidates the digest. Extended attributes only answer
+// for files the file store does not know, such as ones that were moved.
+func (b *both) Lookup(filename string, info os.FileInfo, algorithm string) (string, bool) {
+	if b.file.known(filename, algorithm) {
+		return b.file.Lookup(filename, info, algorithm)
+	}
+	return b.xattr.Lookup(filename, info, algorithm)
+}
+
+func (b *both) Store(filename string, info os.FileInfo, algorithm, digest string) {
+	b.xattr.Store(filename, info, algorithm, digest)
+	if fresh, err := os.Stat(filename); err == nil {
+		info = fresh
+	}
+	b.file.Store(filename, info, algorithm, digest)
+}
+
+func (b *both) Close() error {
+	return errors.Join(b.xattr.Close(), b.file.Close())
+}
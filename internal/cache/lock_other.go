@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package cache
+
+import "os"
+
+// flock is unavailable here; concurrent saves may drop each other's entries
+func flock(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build linux || darwin
+
+package cache
+
+import (
+	"os"
+	"syscall"
+)
+
+// flock takes an exclusive advisory lock on f, held until f is closed
+func flock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
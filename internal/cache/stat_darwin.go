@@ -0,0 +1,15 @@
+package cache
+
+import (
+	"os"
+	"syscall"
+)
+
+// sysStat returns the change time, inode and device of a file
+func sysStat(info os.FileInfo) (ctime int64, ino, dev uint64) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, 0
+	}
+	return st.Ctimespec.Nano(), st.Ino, uint64(st.Dev)
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package cache
+
+import "os"
+
+// sysStat is unavailable here; digests are validated by size and mtime only
+func sysStat(info os.FileInfo) (ctime int64, ino, dev uint64) {
+	return 0, 0, 0
+}
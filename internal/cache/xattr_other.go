@@ -0,0 +1,10 @@
+//go:build !linux
+
+package cache
+
+import "errors"
+
+// NewXattr reports that extended attribute caching is Linux-only
+func NewXattr() (Cache, error) {
+	return nil, errors.New("xattr cache backend is only supported on Linux")
+}
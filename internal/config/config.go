@@ -0,0 +1,286 @@
+// Package config reads and writes the hashctl settings file shared by the
+// TUI and the CLI commands
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Setting keys, as written in the file
+const (
+	KeyAlgorithm    = "algorithm"
+	KeyFormat       = "format"
+	KeyParallelism  = "parallelism"
+	KeyBcryptCost   = "bcrypt_cost"
+	KeyArgon2Time   = "argon2_time"
+	KeyArgon2Memory = "argon2_memory"
+	KeyArgon2Lanes  = "argon2_lanes"
+	KeyTheme        = "theme"
+	KeyUpdateCheck  = "update_check"
+)
+
+// Keys lists every setting in display order
+var Keys = []string{
+	KeyAlgorithm, KeyFormat, KeyParallelism,
+	KeyBcryptCost, KeyArgon2Time, KeyArgon2Memory, KeyArgon2Lanes,
+	KeyTheme, KeyUpdateCheck,
+}
+
+// Values of update_check
+const (
+	UpdateCheckOn  = "on"
+	UpdateCheckOff = "off"
+)
+
+// Config holds the settings. Zero values are unset and fall back to the
+// built-in defaults.
+type Config struct {
+	Algorithm   string `json:"algorithm,omitempty"`
+	Format      string `json:"format,omitempty"`
+	Parallelism int    `json:"parallelism,omitempty"`
+	BcryptCost  int    `json:"bcrypt_cost,omitempty"`
+	Argon2Time  uint32 `json:"argon2_time,omitempty"`
+	// Argon2Memory is in KiB, as in hasher.Options
+	Argon2Memory uint32 `json:"argon2_memory,omitempty"`
+	Argon2Lanes  uint8  `json:"argon2_lanes,omitempty"`
+	Theme        string `json:"theme,omitempty"`
+	UpdateCheck  string `json:"update_check,omitempty"`
+}
+
+// Defaults returns the built-in value of every setting except the theme,
+// which belongs to the TUI
+func Defaults() Config {
+	opts := hasher.DefaultOptions()
+	return Config{
+		Algorithm:    opts.Algorithm,
+		Format:       hasher.FormatHex,
+		Parallelism:  opts.Parallelism,
+		BcryptCost:   opts.BcryptCost,
+		Argon2Time:   opts.Argon2Time,
+		Argon2Memory: opts.Argon2Memory,
+		Argon2Lanes:  opts.Argon2Lanes,
+		UpdateCheck:  UpdateCheckOn,
+	}
+}
+
+// DefaultPath returns the settings file under the user config directory
+// ($XDG_CONFIG_HOME/hashctl on Linux)
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "hashctl", "config.json")
+}
+
+// EnvVar returns the environment variable overriding a setting, e.g.
+// HASHCTL_ALGORITHM
+func EnvVar(key string) string {
+	return "HASHCTL_" + strings.ToUpper(key)
+}
+
+// Get returns a setting as text, or "" when it is unset
+func (c Config) Get(key string) string {
+	itoa := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	switch key {
+	case KeyAlgorithm:
+		return c.Algorithm
+	case KeyFormat:
+		return c.Format
+	case KeyParallelism:
+		return itoa(c.Parallelism)
+	case KeyBcryptCost:
+		return itoa(c.BcryptCost)
+	case KeyArgon2Time:
+		return itoa(int(c.Argon2Time))
+	case KeyArgon2Memory:
+		return itoa(int(c.Argon2Memory))
+	case KeyArgon2Lanes:
+		return itoa(int(c.Argon2Lanes))
+	case KeyTheme:
+		return c.Theme
+	case KeyUpdateCheck:
+		return c.UpdateCheck
+	}
+	return ""
+}
+
+// Set parses and validates a setting; an empty value unsets it
+func (c *Config) Set(key, value string) error {
+	value = strings.TrimSpace(value)
+	number := func(min, max int) (int, error) {
+		if value == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < min || n > max {
+			return 0, fmt.Errorf("%s must be a number from %d to %d", key, min, max)
+		}
+		return n, nil
+	}
+
+	var err error
+	var n int
+	switch key {
+	case KeyAlgorithm:
+		if _, ok := hasher.GetAlgorithm(value); !ok && value != "" {
+			return fmt.Errorf("unknown algorithm: %s", value)
+		}
+		c.Algorithm = value
+	case KeyFormat:
+		if !slices.Contains(hasher.Formats, value) && value != "" {
+			return fmt.Errorf("format must be one of %s", strings.Join(hasher.Formats, ", "))
+		}
+		c.Format = value
+	case KeyParallelism:
+		n, err = number(0, 1024)
+		c.Parallelism = n
+	case KeyBcryptCost:
+		n, err = number(bcrypt.MinCost, bcrypt.MaxCost)
+		c.BcryptCost = n
+	case KeyArgon2Time:
+		n, err = number(1, 100)
+		c.Argon2Time = uint32(n)
+	case KeyArgon2Memory:
+		n, err = number(8, 4*1024*1024)
+		c.Argon2Memory = uint32(n)
+	case KeyArgon2Lanes:
+		n, err = number(1, 255)
+		c.Argon2Lanes = uint8(n)
+	case KeyTheme:
+		c.Theme = value
+	case KeyUpdateCheck:
+		if value != UpdateCheckOn && value != UpdateCheckOff && value != "" {
+			return fmt.Errorf("update_check must be %s or %s", UpdateCheckOn, UpdateCheckOff)
+		}
+		c.UpdateCheck = value
+	default:
+		return fmt.Errorf("unknown setting %q (use %s)", key, strings.Join(Keys, ", "))
+	}
+	return err
+}
+
+// Merge returns c with every setting that is set in over replaced
+func (c Config) Merge(over Config) Config {
+	for _, key := range Keys {
+		if v := over.Get(key); v != "" {
+			// Both sides were validated when they were set
+			_ = c.Set(key, v)
+		}
+	}
+	return c
+}
+
+// Validate checks every setting, e.g. after the file was edited by hand
+func (c Config) Validate() error {
+	var check Config
+	for _, key := range Keys {
+		if err := check.Set(key, c.Get(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply copies the hashing settings that are set into opts
+func (c Config) Apply(opts *hasher.Options) {
+	if c.Algorithm != "" {
+		opts.Algorithm = c.Algorithm
+	}
+	if c.Parallelism > 0 {
+		opts.Parallelism = c.Parallelism
+	}
+	if c.BcryptCost > 0 {
+		opts.BcryptCost = c.BcryptCost
+	}
+	if c.Argon2Time > 0 {
+		opts.Argon2Time = c.Argon2Time
+	}
+	if c.Argon2Memory > 0 {
+		opts.Argon2Memory = c.Argon2Memory
+	}
+	if c.Argon2Lanes > 0 {
+		opts.Argon2Lanes = c.Argon2Lanes
+	}
+}
+
+// FromEnv reads the settings given as HASHCTL_* environment variables
+func FromEnv(getenv func(string) string) (Config, error) {
+	var c Config
+	for _, key := range Keys {
+		if err := c.Set(key, getenv(EnvVar(key))); err != nil {
+			return Config{}, fmt.Errorf("%s: %w", EnvVar(key), err)
+		}
+	}
+	return c, nil
+}
+
+// Load reads the settings file. A missing file is an empty config.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := c.Validate(); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save atomically writes the settings file
+func (c Config) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.json")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+// Package dupes finds files with identical content
+package dupes
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+)
+
+// Config controls the duplicate search
+type Config struct {
+	// MinSize skips files smaller than this many bytes
+	MinSize int64
+	// PartialSize is how many bytes are read from each end of a file
+	// for the quick pre-filter
+	PartialSize int64
+	// PartialAlgorithm is the checksum used for the pre-filter
+	PartialAlgorithm string
+}
+
+// DefaultConfig returns sensible defaults
+func DefaultConfig() Config {
+	return Config{
+		MinSize:          1,
+		PartialSize:      16 * 1024,
+		PartialAlgorithm: "crc32",
+	}
+}
+
+// Group is a set of files with identical content
+type Group struct {
+	Digest string   `json:"digest"`
+	Size   int64    `json:"size"`
+	Files  []string `json:"files"` // sorted; the first file is treated as the original
+}
+
+// Wasted returns the bytes that could be reclaimed by keeping one copy
+func (g Group) Wasted() int64 {
+	return g.Size * int64(len(g.Files)-1)
+}
+
+// Stats summarises a duplicate search
+type Stats struct {
+	Scanned     int   `json:"scanned"`
+	PartialHash int   `json:"partial_hashed"`
+	FullHash    int   `json:"full_hashed"`
+	Wasted      int64 `json:"wasted"`
+}
+
+// Find searches roots for duplicate files. Candidates are narrowed by size,
+// then by a checksum of the first and last PartialSize bytes, and only the
+// remaining files are fully hashed with opts.Algorithm.
+func Find(roots []string, opts hasher.Options, cfg Config) ([]Group, Stats, error) {
+	var stats Stats
+
+	full, ok := hasher.GetAlgorithm(opts.Algorithm)
+	if !ok {
+		return nil, stats, fmt.Errorf("unknown algorithm: %s", opts.Algorithm)
+	}
+	if full.IsPasswordHash {
+		return nil, stats, fmt.Errorf("%s cannot be used to compare files", full.Name)
+	}
+	partial, ok := hasher.GetAlgorithm(cfg.PartialAlgorithm)
+	if !ok || partial.IsPasswordHash {
+		return nil, stats, fmt.Errorf("invalid partial algorithm: %s", cfg.PartialAlgorithm)
+	}
+
+	// Stage 1: group by size, ignoring extra hard links to the same file
+	bySize := make(map[int64][]string)
+	infos := make(map[int64][]os.FileInfo)
+	for _, root := range roots {
+		files, err := hasher.WalkFiles(root)
+		if err != nil {
+			return nil, stats, err
+		}
+		for _, f := range files {
+			info, err := os.Lstat(f)
+			if err != nil || info.Size() < cfg.MinSize {
+				continue
+			}
+			stats.Scanned++
+
+			size := info.Size()
+			if linked(infos[size], info) {
+				continue
+			}
+			bySize[size] = append(bySize[size], f)
+			infos[size] = append(infos[size], info)
+		}
+	}
+
+	// Stage 2: quick checksum of both ends
+	var candidates [][]string
+	for size, files := range bySize {
+		if len(files) < 2 {
+			continue
+		}
+		stats.PartialHash += len(files)
+		byPartial := make(map[string][]string)
+		for i, sum := range partialHashes(files, size, partial, cfg.PartialSize, opts.Parallelism) {
+			if sum != "" {
+				byPartial[sum] = append(byPartial[sum], files[i])
+			}
+		}
+		for _, set := range byPartial {
+			if len(set) > 1 {
+				candidates = append(candidates, set)
+			}
+		}
+	}
+
+	// Stage 3: full cryptographic hash
+	var groups []Group
+	for _, set := range candidates {
+		stats.FullHash += len(set)
+		byDigest := make(map[string][]string)
+		sizes := make(map[string]int64)
+		hasher.HashFiles(set, opts, func(r hasher.Result) {
+			if r.Error != nil {
+				return
+			}
+			byDigest[r.Hash] = append(byDigest[r.Hash], r.Input)
+			sizes[r.Hash] = r.Size
+		})
+		for digest, files := range byDigest {
+			if len(files) < 2 {
+				continue
+			}
+			sort.Strings(files)
+			g := Group{Digest: digest, Size: sizes[digest], Files: files}
+			stats.Wasted += g.Wasted()
+			groups = append(groups, g)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Wasted() != groups[j].Wasted() {
+			return groups[i].Wasted() > groups[j].Wasted()
+		}
+		return groups[i].Files[0] < groups[j].Files[0]
+	})
+	return groups, stats, nil
+}
+
+// linked reports whether info is a hard link to one of seen
+func linked(seen []os.FileInfo, info os.FileInfo) bool {
+	for _, s := range seen {
+		if os.SameFile(s, info) {
+			return true
+		}
+	}
+	return false
+}
+
+// partialHashes checksums the head and tail of each file in parallel.
+// Unreadable files get an empty checksum.
+func partialHashes(files []string, size int64, alg hasher.Algorithm, n int64, parallelism int) []string {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	sums := make([]string, len(files))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+
+	for i, f := range files {
+		i, f := i, f
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			sums[i], _ = partialHash(f, size, alg, n)
+		}()
+	}
+	wg.Wait()
+	return sums
+}
+
+func partialHash(filename string, size int64, alg hasher.Algorithm, n int64) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := alg.NewHash()
+	if size <= 2*n {
+		if _, err := io.Copy(h, f); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	if _, err := io.Copy(h, io.NewSectionReader(f, 0, n)); err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(h, io.NewSectionReader(f, size-n, n)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Script actions for Script
+const (
+	ActionHardlink = "hardlink"
+	ActionDelete   = "delete"
+)
+
+// Script renders a dry-run shell script that replaces each duplicate with a
+// hard link to the group's first file, or deletes it. Every command is
+// commented out so the script must be reviewed before it does anything.
+func Script(groups []Group, action string) (string, error) {
+	if action != ActionHardlink && action != ActionDelete {
+		return "", fmt.Errorf("unknown script action: %s (use %s or %s)", action, ActionHardlink, ActionDelete)
+	}
+
+	var s strings.Builder
+	s.WriteString("#!/bin/sh\n")
+	s.WriteString("# Generated by hashctl dupes (dry run).\n")
+	s.WriteString("# Review, then uncomment the commands you want to run.\n")
+	s.WriteString("set -eu\n")
+
+	for _, g := range groups {
+		fmt.Fprintf(&s, "\n# %s  %d bytes x %d\n", g.Digest, g.Size, len(g.Files))
+		fmt.Fprintf(&s, "# keep %s\n", shellQuote(g.Files[0]))
+		for _, dup := range g.Files[1:] {
+			if action == ActionHardlink {
+				fmt.Fprintf(&s, "# ln -f -- %s %s\n", shellQuote(g.Files[0]), shellQuote(dup))
+			} else {
+				fmt.Fprintf(&s, "# rm -- %s\n", shellQuote(dup))
+			}
+		}
+	}
+	return s.String(), nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
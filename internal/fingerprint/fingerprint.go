@@ -0,0 +1,171 @@
+// Package fingerprint turns digests into pictures and words that people
+// can compare at a glance: OpenSSH randomart, coloured blocks, emoji and
+// the PGP word list
+package fingerprint
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Visual fingerprint kinds
+const (
+	KindRandomart = "randomart"
+	KindBlocks    = "blocks"
+	KindEmoji     = "emoji"
+	KindWords     = "words"
+)
+
+// Kinds lists the fingerprint kinds
+var Kinds = []string{KindRandomart, KindBlocks, KindEmoji, KindWords}
+
+// Decode returns the raw bytes of a hex digest. Password hashes such as
+// bcrypt are salted strings, and two of them never look alike anyway.
+func Decode(digest string) ([]byte, error) {
+	b, err := hex.DecodeString(digest)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("visual fingerprints need a hex digest")
+	}
+	return b, nil
+}
+
+// The randomart board and the symbols for how often a cell was visited,
+// as in OpenSSH
+const (
+	artWidth   = 17
+	artHeight  = 9
+	artSymbols = " .o+=*BOX@%&#/^"
+)
+
+// Randomart draws the OpenSSH "drunken bishop" picture of a digest. The
+// bishop starts in the centre and makes four moves per byte, taking two
+// bits at a time from the least significant end; S and E mark where it
+// started and ended. The title and footer are set into the frame.
+func Randomart(digest []byte, title, footer string) string {
+	var field [artWidth][artHeight]int
+	x, y := artWidth/2, artHeight/2
+	for _, b := range digest {
+		for i := 0; i < 4; i++ {
+			if b&1 != 0 {
+				x++
+			} else {
+				x--
+			}
+			if b&2 != 0 {
+				y++
+			} else {
+				y--
+			}
+			x = min(max(x, 0), artWidth-1)
+			y = min(max(y, 0), artHeight-1)
+			if field[x][y] < len(artSymbols)-1 {
+				field[x][y]++
+			}
+			b >>= 2
+		}
+	}
+
+	var s strings.Builder
+	s.WriteString(frame(title))
+	s.WriteString("\n")
+	for row := 0; row < artHeight; row++ {
+		s.WriteString("|")
+		for col := 0; col < artWidth; col++ {
+			switch {
+			case col == x && row == y:
+				s.WriteString("E")
+			case col == artWidth/2 && row == artHeight/2:
+				s.WriteString("S")
+			default:
+				s.WriteByte(artSymbols[field[col][row]])
+			}
+		}
+		s.WriteString("|\n")
+	}
+	s.WriteString(frame(footer))
+	return s.String()
+}
+
+// frame returns a border line with a label set in the middle, e.g.
+// +---[SHA256]----+
+func frame(label string) string {
+	if label != "" {
+		label = "[" + label + "]"
+	}
+	if len(label) > artWidth {
+		label = label[:artWidth]
+	}
+	left := (artWidth - len(label)) / 2
+	return "+" + strings.Repeat("-", left) + label + strings.Repeat("-", artWidth-left-len(label)) + "+"
+}
+
+// Words encodes a digest with the PGP word list: two-syllable words for
+// bytes at even positions and three-syllable words for odd ones, so a
+// swapped or dropped word is heard as well as seen
+func Words(digest []byte) []string {
+	words := make([]string, len(digest))
+	for i, b := range digest {
+		if i%2 == 0 {
+			words[i] = evenWords[b]
+		} else {
+			words[i] = oddWords[b]
+		}
+	}
+	return words
+}
+
+// emojiRanges are runs of code points that terminals draw as emoji by
+// default, 256 in all: plants and food, celebrations and sports, animals
+// and office objects
+var emojiRanges = [][2]rune{
+	{0x1F330, 0x1F335},
+	{0x1F337, 0x1F37C},
+	{0x1F380, 0x1F393},
+	{0x1F3A0, 0x1F3C4},
+	{0x1F400, 0x1F43E},
+	{0x1F4A0, 0x1F4DB},
+}
+
+var emoji = func() []rune {
+	var runes []rune
+	for _, r := range emojiRanges {
+		for c := r[0]; c <= r[1]; c++ {
+			runes = append(runes, c)
+		}
+	}
+	if len(runes) != 256 {
+		panic(fmt.Sprintf("fingerprint: %d emoji, want 256", len(runes)))
+	}
+	return runes
+}()
+
+// Emoji encodes each byte of a digest as one emoji
+func Emoji(digest []byte) []string {
+	out := make([]string, len(digest))
+	for i, b := range digest {
+		out[i] = string(emoji[b])
+	}
+	return out
+}
+
+// BlockColors are 16 colours that stay apart on dark and light
+// backgrounds, one for each hex digit. They are xterm 256-colour indexes,
+// which terminals draw alike, so two people see the same blocks.
+var BlockColors = [16]string{
+	"196", "34", "226", "27",
+	"208", "93", "51", "201",
+	"154", "218", "30", "183",
+	"94", "88", "100", "248",
+}
+
+// Blocks returns the BlockColors index of every hex digit of a digest,
+// high nibble first
+func Blocks(digest []byte) []int {
+	out := make([]int, 0, 2*len(digest))
+	for _, b := range digest {
+		out = append(out, int(b>>4), int(b&0x0f))
+	}
+	return out
+}
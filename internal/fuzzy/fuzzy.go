@@ -0,0 +1,74 @@
+// Package fuzzy ranks strings against a loosely typed pattern, the way
+// editor file pickers do
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match reports whether every rune of pattern appears in s in order,
+// ignoring case. Higher scores mean a better match: consecutive runs,
+// matches at word starts and matches near the front all count.
+func Match(pattern, s string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+	p := []rune(strings.ToLower(pattern))
+	score, pi, run := 0, 0, 0
+	prev := ' '
+	for i, r := range strings.ToLower(s) {
+		if pi == len(p) {
+			break
+		}
+		if r != p[pi] {
+			run = 0
+			prev = r
+			continue
+		}
+		score++
+		run++
+		score += run * 2
+		if isBoundary(prev) {
+			score += 5
+		}
+		if i < 8 {
+			score += 8 - i
+		}
+		pi++
+		prev = r
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	// Prefer shorter strings among equal matches
+	return score*100 - utf8.RuneCountInString(s), true
+}
+
+func isBoundary(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// Filter returns the indexes of items matching pattern, best match first.
+// An empty pattern keeps every item in its original order.
+func Filter(pattern string, items []string) []int {
+	type hit struct{ index, score int }
+	var hits []hit
+	for i, item := range items {
+		if score, ok := Match(pattern, item); ok {
+			hits = append(hits, hit{i, score})
+		}
+	}
+	if pattern != "" {
+		sort.SliceStable(hits, func(i, j int) bool {
+			return hits[i].score > hits[j].score
+		})
+	}
+	out := make([]int, len(hits))
+	for i, h := range hits {
+		out[i] = h.index
+	}
+	return out
+}
@@ -44,6 +44,10 @@ type Algorithm struct {
 	NewHash     func() hash.Hash
 	// For password hashes, we use a different interface
 	IsPasswordHash bool
+	// Multicodec is the multihash function code, zero if the algorithm has none
+	Multicodec uint64
+	// Aliases are other common spellings, used when searching
+	Aliases []string
 }
 
 // Registry holds all available algorithms
@@ -51,113 +55,143 @@ var Registry = map[string]Algorithm{
 	// Checksums (Non-Cryptographic)
 	"crc32": {
 		Name:        "CRC32",
+		Aliases:     []string{"crc", "crc-32"},
 		Description: "Fast checksum for detecting accidental data corruption; not suitable for security.",
 		Category:    CategoryChecksum,
 		NewHash:     func() hash.Hash { return crc32.NewIEEE() },
+		Multicodec:  0x0132,
 	},
 
 	// Fast Cryptographic Hashes
 	"md5": {
 		Name:        "MD5",
+		Aliases:     []string{"md-5"},
 		Description: "128-bit hash, widely used but cryptographically broken. Use only for legacy compatibility.",
 		Category:    CategoryFastHash,
 		NewHash:     md5.New,
+		Multicodec:  0xd5,
 	},
 	"sha1": {
 		Name:        "SHA-1",
+		Aliases:     []string{"sha-1"},
 		Description: "160-bit hash, deprecated for security use. Common in legacy systems and git.",
 		Category:    CategoryFastHash,
 		NewHash:     sha1.New,
+		Multicodec:  0x11,
 	},
 	"sha224": {
 		Name:        "SHA-224",
+		Aliases:     []string{"sha-224", "sha2-224"},
 		Description: "Truncated variant of SHA-256 with 224-bit output.",
 		Category:    CategoryFastHash,
 		NewHash:     sha256.New224,
+		Multicodec:  0x1013,
 	},
 	"sha256": {
 		Name:        "SHA-256",
+		Aliases:     []string{"sha-256", "sha2-256"},
 		Description: "Cryptographic hash widely used for integrity checks and content addressing.",
 		Category:    CategoryFastHash,
 		NewHash:     sha256.New,
+		Multicodec:  0x12,
 	},
 	"sha384": {
 		Name:        "SHA-384",
+		Aliases:     []string{"sha-384", "sha2-384"},
 		Description: "Truncated variant of SHA-512 with 384-bit output.",
 		Category:    CategoryFastHash,
 		NewHash:     sha512.New384,
+		Multicodec:  0x20,
 	},
 	"sha512": {
 		Name:        "SHA-512",
+		Aliases:     []string{"sha-512", "sha2-512"},
 		Description: "512-bit hash from the SHA-2 family, suitable for high-security applications.",
 		Category:    CategoryFastHash,
 		NewHash:     sha512.New,
+		Multicodec:  0x13,
 	},
 	"sha512-224": {
 		Name:        "SHA-512/224",
+		Aliases:     []string{"sha-512/224"},
 		Description: "SHA-512 truncated to 224 bits, optimized for 64-bit platforms.",
 		Category:    CategoryFastHash,
 		NewHash:     sha512.New512_224,
+		Multicodec:  0x1014,
 	},
 	"sha512-256": {
 		Name:        "SHA-512/256",
+		Aliases:     []string{"sha-512/256"},
 		Description: "SHA-512 truncated to 256 bits, optimized for 64-bit platforms.",
 		Category:    CategoryFastHash,
 		NewHash:     sha512.New512_256,
+		Multicodec:  0x1015,
 	},
 	"sha3-224": {
 		Name:        "SHA3-224",
 		Description: "224-bit SHA-3 hash based on Keccak sponge construction.",
 		Category:    CategoryFastHash,
 		NewHash:     sha3.New224,
+		Multicodec:  0x17,
 	},
 	"sha3-256": {
 		Name:        "SHA3-256",
 		Description: "256-bit SHA-3 hash, NIST standard alternative to SHA-256.",
 		Category:    CategoryFastHash,
 		NewHash:     sha3.New256,
+		Multicodec:  0x16,
 	},
 	"sha3-384": {
 		Name:        "SHA3-384",
 		Description: "384-bit SHA-3 hash based on Keccak sponge construction.",
 		Category:    CategoryFastHash,
 		NewHash:     sha3.New384,
+		Multicodec:  0x15,
 	},
 	"sha3-512": {
 		Name:        "SHA3-512",
 		Description: "512-bit SHA-3 hash, highest security level in SHA-3 family.",
 		Category:    CategoryFastHash,
 		NewHash:     sha3.New512,
+		Multicodec:  0x14,
 	},
 	"ripemd160": {
 		Name:        "RIPEMD-160",
+		Aliases:     []string{"ripemd-160", "rmd160"},
 		Description: "160-bit hash used in Bitcoin addresses and PGP fingerprints.",
 		Category:    CategoryFastHash,
 		NewHash:     ripemd160.New,
+		Multicodec:  0x1053,
 	},
 	"blake2b-256": {
 		Name:        "BLAKE2b-256",
 		Description: "Fast cryptographic hash, faster than MD5 while being secure.",
 		Category:    CategoryFastHash,
 		NewHash:     func() hash.Hash { h, _ := NewBlake2b256(); return h },
+		Multicodec:  0xb220,
 	},
 	"blake2b-384": {
 		Name:        "BLAKE2b-384",
 		Description: "384-bit BLAKE2b variant, optimized for 64-bit platforms.",
 		Category:    CategoryFastHash,
 		NewHash:     func() hash.Hash { h, _ := NewBlake2b384(); return h },
+		Multicodec:  0xb230,
 	},
 	"blake2b-512": {
 		Name:        "BLAKE2b-512",
+		Aliases:     []string{"blake2b", "b2sum"},
 		Description: "512-bit BLAKE2b, one of the fastest secure hash functions.",
 		Category:    CategoryFastHash,
 		NewHash:     func() hash.Hash { h, _ := NewBlake2b512(); return h },
+		Multicodec:  0xb240,
 	},
 	"blake2s-256": {
 		Name:        "BLAKE2s-256",
+		Aliases:     []string{"blake2s"},
 		Description: "BLAKE2s optimized for 8-32 bit platforms and small inputs.",
 		Category:    CategoryFastHash,
 		NewHash:     func() hash.Hash { h, _ := NewBlake2s256(); return h },
+		Multicodec:  0xb260,
 	},
 
 	// Password Hashing / KDFs
@@ -169,6 +203,7 @@ var Registry = map[string]Algorithm{
 	},
 	"argon2id": {
 		Name:           "Argon2id",
+		Aliases:        []string{"argon2"},
 		Description:    "Memory-hard password hashing algorithm designed to resist GPU and ASIC attacks.",
 		Category:       CategoryPasswordHash,
 		IsPasswordHash: true,
@@ -220,4 +255,3 @@ func ListNames() []string {
 	sort.Strings(names)
 	return names
 }
-
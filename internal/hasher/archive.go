@@ -0,0 +1,211 @@
+package hasher
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ArchiveSeparator joins an archive path and the entry path in results
+const ArchiveSeparator = "!"
+
+// Merkle tree domain separation prefixes
+const (
+	merkleLeafPrefix = 0x00
+	merkleNodePrefix = 0x01
+)
+
+// Position and value of the ustar magic in POSIX and GNU tar headers
+const (
+	tarMagic       = "ustar"
+	tarMagicOffset = 257
+)
+
+// ArchiveEntryName returns the result label for an entry inside an archive
+func ArchiveEntryName(archive, entry string) string {
+	return archive + ArchiveSeparator + entry
+}
+
+// ErrIncompleteArchive means some entries of an archive could not be read,
+// so a Merkle root would not cover the whole archive
+var ErrIncompleteArchive = errors.New("no Merkle root for an incomplete archive")
+
+// HashArchive hashes every regular file inside a tar (plain, gzip or bzip2
+// compressed) or zip archive without extracting it. Each entry is reported
+// through onResult as "archive!path" in archive order.
+//
+// The returned Merkle root covers the sorted (path, digest) pairs of all
+// entries, so it depends only on the archived content and not on entry
+// order or compression. When an entry cannot be read there is no root and
+// the error wraps ErrIncompleteArchive.
+func HashArchive(filename string, opts Options, onResult func(Result)) (string, error) {
+	alg, ok := GetAlgorithm(opts.Algorithm)
+	if !ok {
+		return "", fmt.Errorf("unknown algorithm: %s", opts.Algorithm)
+	}
+	if alg.IsPasswordHash {
+		return "", fmt.Errorf("%s cannot be used to hash archive entries", alg.Name)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var leaves []merkleLeaf
+	failed := 0
+	emit := func(r Result) {
+		if r.Error == nil {
+			digest, _ := hex.DecodeString(r.Hash)
+			leaves = append(leaves, merkleLeaf{path: r.Input, digest: digest})
+		} else {
+			failed++
+		}
+		r.Input = ArchiveEntryName(filename, r.Input)
+		r.IsFile = true
+		onResult(r)
+	}
+
+	// A tar header is checked first: its entry name comes first in the
+	// file and may itself start with "PK" or "BZh"
+	br := bufio.NewReader(f)
+	magic, _ := br.Peek(tarMagicOffset + len(tarMagic))
+	switch {
+	case len(magic) == tarMagicOffset+len(tarMagic) && bytes.Equal(magic[tarMagicOffset:], []byte(tarMagic)):
+		if err := hashTar(br, opts, emit); err != nil {
+			return "", err
+		}
+	case bytes.HasPrefix(magic, []byte("PK\x03\x04")), bytes.HasPrefix(magic, []byte("PK\x05\x06")):
+		info, err := f.Stat()
+		if err != nil {
+			return "", err
+		}
+		err = hashZip(f, info.Size(), opts, emit)
+		if err != nil {
+			return "", err
+		}
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return "", err
+		}
+		defer gz.Close()
+		if err := hashTar(gz, opts, emit); err != nil {
+			return "", err
+		}
+	case bytes.HasPrefix(magic, []byte("BZh")):
+		if err := hashTar(bzip2.NewReader(br), opts, emit); err != nil {
+			return "", err
+		}
+	default:
+		if err := hashTar(br, opts, emit); err != nil {
+			return "", err
+		}
+	}
+
+	if failed > 0 {
+		return "", fmt.Errorf("%w: %d entries failed", ErrIncompleteArchive, failed)
+	}
+	return hex.EncodeToString(merkleRoot(alg.NewHash, leaves)), nil
+}
+
+func hashTar(r io.Reader, opts Options, emit func(Result)) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		emit(HashReader(cleanEntryName(hdr.Name), tr, opts))
+	}
+}
+
+func hashZip(r io.ReaderAt, size int64, opts Options, emit func(Result)) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+		name := cleanEntryName(zf.Name)
+
+		rc, err := zf.Open()
+		if err != nil {
+			emit(Result{Input: name, Error: err})
+			continue
+		}
+		emit(HashReader(name, rc, opts))
+		rc.Close()
+	}
+	return nil
+}
+
+func cleanEntryName(name string) string {
+	return path.Clean(strings.TrimPrefix(name, "./"))
+}
+
+type merkleLeaf struct {
+	path   string
+	digest []byte
+}
+
+// merkleRoot builds a binary Merkle tree over leaves sorted by path. Leaves
+// hash 0x00 || path || 0x00 || digest, inner nodes hash 0x01 || left || right,
+// and an odd node is promoted unchanged to the next level.
+func merkleRoot(newHash func() hash.Hash, leaves []merkleLeaf) []byte {
+	sort.Slice(leaves, func(i, j int) bool {
+		return leaves[i].path < leaves[j].path
+	})
+
+	level := make([][]byte, 0, len(leaves))
+	for _, l := range leaves {
+		h := newHash()
+		h.Write([]byte{merkleLeafPrefix})
+		h.Write([]byte(l.path))
+		h.Write([]byte{0})
+		h.Write(l.digest)
+		level = append(level, h.Sum(nil))
+	}
+
+	if len(level) == 0 {
+		return newHash().Sum(nil)
+	}
+
+	for len(level) > 1 {
+		var next [][]byte
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			h := newHash()
+			h.Write([]byte{merkleNodePrefix})
+			h.Write(level[i])
+			h.Write(level[i+1])
+			next = append(next, h.Sum(nil))
+		}
+		level = next
+	}
+	return level[0]
+}
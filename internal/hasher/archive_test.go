@@ -0,0 +1,154 @@
+package hasher
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type archiveFile struct {
+	name string
+	data string
+}
+
+var archiveFiles = []archiveFile{
+	{"BZh.txt", "a tar starting with this name looks like bzip2\n"},
+	{"hello.txt", "hello world\n"},
+	{"dir/a.txt", "a\n"},
+	{"dir/sub/b.txt", "b\n"},
+	{"empty", ""},
+}
+
+func writeTar(w io.Writer, files []archiveFile) error {
+	tw := tar.NewWriter(w)
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.data)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(tw, f.data); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func writeTarGz(w io.Writer, files []archiveFile) error {
+	gz := gzip.NewWriter(w)
+	if err := writeTar(gz, files); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeZip(w io.Writer, files []archiveFile) error {
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, f.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func TestHashArchiveMerkleRoot(t *testing.T) {
+	reversed := make([]archiveFile, len(archiveFiles))
+	for i, f := range archiveFiles {
+		reversed[len(archiveFiles)-1-i] = f
+	}
+
+	tests := []struct {
+		name  string
+		write func(io.Writer, []archiveFile) error
+		files []archiveFile
+	}{
+		{"files.tar", writeTar, archiveFiles},
+		{"files.tar.gz", writeTarGz, archiveFiles},
+		{"files.zip", writeZip, archiveFiles},
+		{"reversed.tar", writeTar, reversed},
+		{"reversed.zip", writeZip, reversed},
+	}
+
+	for _, algorithm := range []string{"sha256", "blake2b-256"} {
+		t.Run(algorithm, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Algorithm = algorithm
+			dir := t.TempDir()
+
+			var want string
+			for _, tt := range tests {
+				path := filepath.Join(dir, tt.name)
+				f, err := os.Create(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := tt.write(f, tt.files); err != nil {
+					t.Fatal(err)
+				}
+				if err := f.Close(); err != nil {
+					t.Fatal(err)
+				}
+
+				entries := 0
+				root, err := HashArchive(path, opts, func(r Result) {
+					if r.Error != nil {
+						t.Errorf("%s: %v", r.Input, r.Error)
+					}
+					entries++
+				})
+				if err != nil {
+					t.Fatalf("%s: HashArchive: %v", tt.name, err)
+				}
+				if entries != len(archiveFiles) {
+					t.Errorf("%s: %d entries, want %d", tt.name, entries, len(archiveFiles))
+				}
+				if want == "" {
+					want = root
+				} else if root != want {
+					t.Errorf("%s: root %s, want %s", tt.name, root, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHashArchiveRootChanges(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Algorithm = "sha256"
+	dir := t.TempDir()
+
+	changed := append([]archiveFile(nil), archiveFiles...)
+	changed[1].data = "hello world!\n"
+	renamed := append([]archiveFile(nil), archiveFiles...)
+	renamed[1].name = "hello2.txt"
+
+	roots := make(map[string]string)
+	for name, files := range map[string][]archiveFile{"same": archiveFiles, "changed": changed, "renamed": renamed} {
+		path := filepath.Join(dir, name+".tar")
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writeTar(f, files); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+
+		root, err := HashArchive(path, opts, func(Result) {})
+		if err != nil {
+			t.Fatalf("%s: HashArchive: %v", name, err)
+		}
+		if other, ok := roots[root]; ok {
+			t.Errorf("%s and %s have the same root %s", name, other, root)
+		}
+		roots[root] = name
+	}
+}
@@ -0,0 +1,143 @@
+package hasher
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Expected is a reference digest to compare results against
+type Expected struct {
+	Raw string
+	// Digest is the decoded digest; nil for password hashes such as bcrypt,
+	// which are checked with VerifyPassword instead
+	Digest   []byte
+	Encoding string
+	// Algorithm is named by a prefix ("sha256:…", "$2b$…") or guessed from
+	// the digest length; empty when nothing fits
+	Algorithm string
+	// Explicit is true when the algorithm came from a prefix, not a guess
+	Explicit bool
+	// Alternatives are other algorithms with the same digest length
+	Alternatives []string
+}
+
+// ParseExpected decodes an expected digest given as hex in either case,
+// base64, "algorithm:hex", SRI "sha384-base64", a multihash or a bcrypt hash
+func ParseExpected(s string) (Expected, error) {
+	id := Identify(s)
+	e := Expected{Raw: id.Input, Encoding: id.Encoding}
+	if id.Input == "" {
+		return e, errors.New("empty expected hash")
+	}
+	if len(id.Candidates) == 0 {
+		return e, fmt.Errorf("unrecognised expected hash %q", id.Input)
+	}
+
+	best := id.Candidates[0]
+	switch id.Encoding {
+	case "modular crypt":
+		if best.Algorithm == "" {
+			return e, fmt.Errorf("%s hashes are not supported", best.Name)
+		}
+		// hashctl's argon2id output is a raw hex key with a fixed salt;
+		// PHC strings carry their own salt and parameters
+		if best.Algorithm == "argon2id" {
+			return e, errors.New("PHC argon2id strings are not supported: only hashctl's raw argon2id output can be verified")
+		}
+		e.Algorithm = best.Algorithm
+		e.Explicit = true
+		return e, nil
+	case "multihash":
+		data, _ := hex.DecodeString(id.Input)
+		mh, err := DecodeMultihash(data)
+		if err != nil {
+			return e, err
+		}
+		e.Digest = mh.Digest
+		e.Algorithm = mh.Algorithm
+		e.Explicit = true
+		return e, nil
+	case "cid":
+		c, err := ParseContentID(id.Input)
+		if err != nil {
+			return e, err
+		}
+		e.Digest = c.Multihash.Digest
+		e.Algorithm = c.Multihash.Algorithm
+		e.Explicit = true
+		return e, nil
+	}
+
+	digest, err := decodeDigest(id.Input, id.Encoding)
+	if err != nil {
+		return e, err
+	}
+	e.Digest = digest
+	e.Algorithm = best.Algorithm
+	e.Explicit = best.Score >= 90
+	if !e.Explicit {
+		for _, c := range id.Candidates[1:] {
+			if c.Algorithm != "" && !Registry[c.Algorithm].IsPasswordHash {
+				e.Alternatives = append(e.Alternatives, c.Algorithm)
+			}
+		}
+	}
+	return e, nil
+}
+
+// decodeDigest extracts the digest bytes from hex, base64 and prefixed forms
+func decodeDigest(s, encoding string) ([]byte, error) {
+	body := s
+	if label, rest, ok := strings.Cut(s, ":"); ok && isAlgorithmLabel(label) {
+		body = rest
+	} else if label, rest, ok := strings.Cut(s, "-"); ok && isAlgorithmLabel(label) && encoding == "base64" {
+		body = rest
+	}
+
+	if encoding == "hex" {
+		return hex.DecodeString(body)
+	}
+	if data, ok := decodeBase64(body); ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("cannot decode %s digest", encoding)
+}
+
+// Hex returns the expected digest as lowercase hex, or the raw string for
+// password hashes
+func (e Expected) Hex() string {
+	if e.Digest == nil {
+		return e.Raw
+	}
+	return hex.EncodeToString(e.Digest)
+}
+
+// Match compares a result against the expected digest in constant time.
+// Password hashes such as bcrypt are salted, so for them the result's input
+// (or the contents of its file) is verified instead.
+func (e Expected) Match(r Result, opts Options) (bool, error) {
+	if r.Error != nil {
+		return false, r.Error
+	}
+	if e.Digest == nil {
+		password := r.Input
+		if r.IsFile {
+			data, err := os.ReadFile(r.Input)
+			if err != nil {
+				return false, err
+			}
+			password = string(data)
+		}
+		return VerifyPassword(password, e.Raw, opts)
+	}
+
+	got, err := hex.DecodeString(r.Hash)
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(got, e.Digest) == 1, nil
+}
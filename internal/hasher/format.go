@@ -0,0 +1,58 @@
+package hasher
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// Output formats for digests
+const (
+	FormatHex       = "hex"
+	FormatMultihash = "multihash"
+	FormatCID       = "cid"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatHex, FormatMultihash, FormatCID}
+
+// FormatDigest re-encodes a hex digest produced with the given algorithm
+func FormatDigest(hexHash, algorithm, format string) (string, error) {
+	switch format {
+	case "", FormatHex:
+		return hexHash, nil
+	case FormatMultihash, FormatCID:
+	default:
+		return "", fmt.Errorf("unknown output format: %s", format)
+	}
+
+	alg, ok := GetAlgorithm(algorithm)
+	if !ok {
+		return "", fmt.Errorf("unknown algorithm: %s", algorithm)
+	}
+	if alg.IsPasswordHash {
+		return "", fmt.Errorf("%s output cannot be encoded as %s", alg.Name, format)
+	}
+
+	digest, err := hex.DecodeString(hexHash)
+	if err != nil {
+		return "", fmt.Errorf("invalid digest: %w", err)
+	}
+
+	if format == FormatCID {
+		return RawLeafCID(algorithm, digest)
+	}
+	mh, err := EncodeMultihash(algorithm, digest)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(mh), nil
+}
+
+// FormatResult re-encodes a result's digest, refusing CIDs for inputs that
+// would not be stored as a single raw block
+func FormatResult(r Result, algorithm, format string) (string, error) {
+	if format == FormatCID && r.Size > DefaultChunkSize {
+		return "", fmt.Errorf("%w (%d > %d bytes)", ErrBlockTooLarge, r.Size, DefaultChunkSize)
+	}
+	return FormatDigest(r.Hash, algorithm, format)
+}
@@ -0,0 +1,271 @@
+package hasher
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Git object formats, named as in `git init --object-format`
+const (
+	GitObjectSHA1   = "sha1"
+	GitObjectSHA256 = "sha256"
+)
+
+// Git tree entry modes
+const (
+	GitModeFile       = "100644"
+	GitModeExecutable = "100755"
+	GitModeSymlink    = "120000"
+	GitModeTree       = "40000"
+)
+
+// GitTreeEntry is a single entry of a git tree object
+type GitTreeEntry struct {
+	Mode string
+	Name string
+	ID   []byte
+}
+
+// GitCommit describes the fields of a git commit object
+type GitCommit struct {
+	Tree      string
+	Parents   []string
+	Author    string // "Name <email> <unix-seconds> <+hhmm>"
+	Committer string // defaults to Author
+	Message   string
+}
+
+func newGitHash(format string) (hash.Hash, error) {
+	switch format {
+	case "", GitObjectSHA1:
+		return Registry["sha1"].NewHash(), nil
+	case GitObjectSHA256:
+		return Registry["sha256"].NewHash(), nil
+	default:
+		return nil, fmt.Errorf("unknown git object format: %s", format)
+	}
+}
+
+// hashGitObject hashes "<kind> <len>\0" followed by the object body
+func hashGitObject(format, kind string, size int64, body io.Reader) ([]byte, error) {
+	h, err := newGitHash(format)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(h, "%s %d\x00", kind, size)
+	n, err := io.Copy(h, body)
+	if err != nil {
+		return nil, err
+	}
+	if n != size {
+		return nil, fmt.Errorf("%s changed size while hashing (%d != %d bytes)", kind, n, size)
+	}
+	return h.Sum(nil), nil
+}
+
+// GitBlobID returns the object ID `git hash-object` would assign to data
+func GitBlobID(data []byte, format string) (string, error) {
+	id, err := hashGitObject(format, "blob", int64(len(data)), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// GitFileBlobID streams a file through the blob hash. Symlinks hash their
+// target path, as git stores them.
+func GitFileBlobID(filename string, format string) (string, error) {
+	id, err := gitFileBlob(filename, format)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func gitFileBlob(filename string, format string) ([]byte, error) {
+	info, err := os.Lstat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(filename)
+		if err != nil {
+			return nil, err
+		}
+		target = filepath.ToSlash(target)
+		return hashGitObject(format, "blob", int64(len(target)), strings.NewReader(target))
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s: not a regular file", filename)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return hashGitObject(format, "blob", info.Size(), f)
+}
+
+// GitTreeID returns the tree object ID of a directory as `git write-tree`
+// would compute it after `git add -A`. Empty directories are omitted and
+// .git directories are skipped; ignore rules are not applied.
+func GitTreeID(dir string, format string) (string, error) {
+	id, err := gitTree(dir, format)
+	if err != nil {
+		return "", err
+	}
+	if id == nil {
+		id, err = hashGitObject(format, "tree", 0, bytes.NewReader(nil))
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// gitTree returns nil for directories with no trackable content
+func gitTree(dir string, format string) ([]byte, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []GitTreeEntry
+	for _, de := range dirEntries {
+		if de.Name() == ".git" {
+			continue
+		}
+		path := filepath.Join(dir, de.Name())
+
+		info, err := os.Lstat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var entry GitTreeEntry
+		switch {
+		case info.IsDir():
+			id, err := gitTree(path, format)
+			if err != nil {
+				return nil, err
+			}
+			if id == nil {
+				continue
+			}
+			entry = GitTreeEntry{Mode: GitModeTree, ID: id}
+		case info.Mode()&os.ModeSymlink != 0:
+			id, err := gitFileBlob(path, format)
+			if err != nil {
+				return nil, err
+			}
+			entry = GitTreeEntry{Mode: GitModeSymlink, ID: id}
+		case info.Mode().IsRegular():
+			id, err := gitFileBlob(path, format)
+			if err != nil {
+				return nil, err
+			}
+			mode := GitModeFile
+			if info.Mode()&0o111 != 0 {
+				mode = GitModeExecutable
+			}
+			entry = GitTreeEntry{Mode: mode, ID: id}
+		default:
+			// Sockets, devices and pipes cannot be tracked by git
+			continue
+		}
+		entry.Name = de.Name()
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return GitTreeObjectID(entries, format)
+}
+
+// GitTreeObjectID sorts entries the way git does and hashes the tree object
+func GitTreeObjectID(entries []GitTreeEntry, format string) ([]byte, error) {
+	sorted := make([]GitTreeEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return gitSortKey(sorted[i]) < gitSortKey(sorted[j])
+	})
+
+	var body bytes.Buffer
+	for _, e := range sorted {
+		body.WriteString(e.Mode)
+		body.WriteByte(' ')
+		body.WriteString(e.Name)
+		body.WriteByte(0)
+		body.Write(e.ID)
+	}
+	return hashGitObject(format, "tree", int64(body.Len()), &body)
+}
+
+// gitSortKey orders trees as if their name had a trailing slash
+func gitSortKey(e GitTreeEntry) string {
+	if e.Mode == GitModeTree {
+		return e.Name + "/"
+	}
+	return e.Name
+}
+
+// GitCommitID hashes a commit object built from c
+func GitCommitID(c GitCommit, format string) (string, error) {
+	if c.Tree == "" {
+		return "", fmt.Errorf("commit requires a tree id")
+	}
+	if c.Author == "" {
+		return "", fmt.Errorf("commit requires an author")
+	}
+	for _, id := range append([]string{c.Tree}, c.Parents...) {
+		if err := checkGitID(id, format); err != nil {
+			return "", err
+		}
+	}
+	committer := c.Committer
+	if committer == "" {
+		committer = c.Author
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "tree %s\n", c.Tree)
+	for _, p := range c.Parents {
+		fmt.Fprintf(&body, "parent %s\n", p)
+	}
+	fmt.Fprintf(&body, "author %s\n", c.Author)
+	fmt.Fprintf(&body, "committer %s\n", committer)
+	body.WriteString("\n")
+	body.WriteString(c.Message)
+
+	id, err := hashGitObject(format, "commit", int64(body.Len()), &body)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// checkGitID verifies id is a hex object ID of the format's length
+func checkGitID(id, format string) error {
+	h, err := newGitHash(format)
+	if err != nil {
+		return err
+	}
+	raw, err := hex.DecodeString(id)
+	if err != nil || len(raw) != h.Size() {
+		return fmt.Errorf("invalid %s object id: %q", format, id)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package hasher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The expected IDs come from git 2.39 in repositories created with
+// `git init --object-format=sha1` and `--object-format=sha256`
+
+func TestGitBlobID(t *testing.T) {
+	tests := []struct {
+		data   string
+		format string
+		want   string // git hash-object
+	}{
+		{"", GitObjectSHA1, "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"},
+		{"hello world\n", GitObjectSHA1, "3b18e512dba79e4c8300dd08aeb37f8e728b8dad"},
+		{"", GitObjectSHA256, "473a0f4c3be8a93681a267e3b1e9a7dcda1185436fe141f7749120a303721813"},
+		{"hello world\n", GitObjectSHA256, "0bd69098bd9b9cc5934a610ab65da429b525361147faa7b5b922919e9a23143d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format+"/"+tt.data, func(t *testing.T) {
+			got, err := GitBlobID([]byte(tt.data), tt.format)
+			if err != nil {
+				t.Fatalf("GitBlobID: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GitBlobID = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitTreeID(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string, mode os.FileMode) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a.txt and a/ check git's tree ordering; empty/ is left out
+	write("hello.txt", "hello world\n", 0o644)
+	write("run.sh", "#!/bin/sh\n", 0o755)
+	write("a/b.txt", "b\n", 0o644)
+	write("a.txt", "a\n", 0o644)
+	if err := os.Symlink("hello.txt", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "empty"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir    string
+		format string
+		want   string // git add -A && git write-tree
+	}{
+		{dir, GitObjectSHA1, "0e6caae5a729d4bb384a7bf5bbb8bbad0ed34747"},
+		{dir, GitObjectSHA256, "a02bf7273a6374cda6a77c0304829fe38bfd34924d6705e69100ffa1b4895c78"},
+		{filepath.Join(dir, "empty"), GitObjectSHA1, "4b825dc642cb6eb9a060e54bf8d69288fbee4904"},
+		{filepath.Join(dir, "empty"), GitObjectSHA256, "6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format+"/"+filepath.Base(tt.dir), func(t *testing.T) {
+			got, err := GitTreeID(tt.dir, tt.format)
+			if err != nil {
+				t.Fatalf("GitTreeID: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GitTreeID = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitCommitID(t *testing.T) {
+	const author = "A U Thor <author@example.com> 1112911993 -0700"
+
+	tests := []struct {
+		name   string
+		format string
+		commit GitCommit
+		want   string // git commit-tree
+	}{
+		{
+			name:   "root",
+			format: GitObjectSHA1,
+			commit: GitCommit{
+				Tree:    "4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+				Author:  author,
+				Message: "initial\n",
+			},
+			want: "551742545a1086034ca8929263580794c5a96a33",
+		},
+		{
+			name:   "parent",
+			format: GitObjectSHA1,
+			commit: GitCommit{
+				Tree:    "0e6caae5a729d4bb384a7bf5bbb8bbad0ed34747",
+				Parents: []string{"551742545a1086034ca8929263580794c5a96a33"},
+				Author:  author,
+				Message: "second\n",
+			},
+			want: "68059b1f78139cf42b3f5a79591397ebf9721916",
+		},
+		{
+			name:   "root",
+			format: GitObjectSHA256,
+			commit: GitCommit{
+				Tree:    "6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321",
+				Author:  author,
+				Message: "initial\n",
+			},
+			want: "48d60ffefa6d3dd28b578ebf45b920dd97f6c9ed15f4cd14b494cf30a2742b8b",
+		},
+		{
+			name:   "parent",
+			format: GitObjectSHA256,
+			commit: GitCommit{
+				Tree:    "a02bf7273a6374cda6a77c0304829fe38bfd34924d6705e69100ffa1b4895c78",
+				Parents: []string{"48d60ffefa6d3dd28b578ebf45b920dd97f6c9ed15f4cd14b494cf30a2742b8b"},
+				Author:  author,
+				Message: "second\n",
+			},
+			want: "3c50a29290df4fb0bb4a7a448f49b338fbac66f3dd14476dee2d52e3d793ae91",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format+"/"+tt.name, func(t *testing.T) {
+			got, err := GitCommitID(tt.commit, tt.format)
+			if err != nil {
+				t.Fatalf("GitCommitID: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GitCommitID = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// A SHA-1 tree ID is refused in a SHA-256 commit
+	if _, err := GitCommitID(tests[0].commit, GitObjectSHA256); err == nil {
+		t.Error("GitCommitID accepted a sha1 tree id for sha256")
+	}
+}
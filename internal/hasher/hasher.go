@@ -1,12 +1,14 @@
 package hasher
 
 import (
+	"crypto/subtle"
 	"encoding/hex"
 	"fmt"
 	"hash"
 	"io"
 	"os"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -21,9 +23,50 @@ type Result struct {
 	Hash     string // hex-encoded hash
 	Error    error  // any error that occurred
 	IsFile   bool   // true if input is a file
+	Size     int64  // number of bytes hashed
+	Cached   bool   // true if the digest came from Options.Cache
 	Duration time.Duration
 }
 
+// DigestCache remembers file digests between runs. Implementations decide
+// whether a cached digest is still valid for the file's current metadata.
+type DigestCache interface {
+	Lookup(filename string, info os.FileInfo, algorithm string) (string, bool)
+	Store(filename string, info os.FileInfo, algorithm, digest string)
+}
+
+// Observer is notified of every hash computed with the options it is
+// attached to, e.g. to record metrics or logs. Implementations must be safe
+// for concurrent use.
+type Observer interface {
+	Observe(algorithm string, r Result)
+}
+
+// Observers combines several observers into one, skipping nil entries
+func Observers(obs ...Observer) Observer {
+	var list multiObserver
+	for _, o := range obs {
+		if o != nil {
+			list = append(list, o)
+		}
+	}
+	switch len(list) {
+	case 0:
+		return nil
+	case 1:
+		return list[0]
+	}
+	return list
+}
+
+type multiObserver []Observer
+
+func (m multiObserver) Observe(algorithm string, r Result) {
+	for _, o := range m {
+		o.Observe(algorithm, r)
+	}
+}
+
 // Options for hash computation
 type Options struct {
 	Algorithm   string
@@ -34,6 +77,10 @@ type Options struct {
 	Argon2Memory uint32
 	Argon2Lanes  uint8
 	Argon2KeyLen uint32
+	// Cache is consulted before reading files; nil disables caching
+	Cache DigestCache
+	// Observer is notified after every computation; nil disables it
+	Observer Observer
 }
 
 // DefaultOptions returns sensible defaults
@@ -72,13 +119,14 @@ func HashString(input string, opts Options) Result {
 		hashStr = hex.EncodeToString(h.Sum(nil))
 	}
 
-	return Result{
+	return observed(opts, Result{
 		Input:    input,
 		Hash:     hashStr,
 		Error:    err,
 		IsFile:   false,
+		Size:     int64(len(input)),
 		Duration: time.Since(start),
-	}
+	})
 }
 
 // HashFile computes the hash of a file using streaming
@@ -98,31 +146,64 @@ func HashFile(filename string, opts Options) Result {
 	if alg.IsPasswordHash {
 		data, err := os.ReadFile(filename)
 		if err != nil {
-			return Result{
-				Input:  filename,
-				Error:  err,
-				IsFile: true,
-			}
+			return observed(opts, Result{
+				Input:    filename,
+				Error:    err,
+				IsFile:   true,
+				Duration: time.Since(start),
+			})
 		}
 		hashStr, err := hashPassword(string(data), opts)
-		return Result{
+		return observed(opts, Result{
 			Input:    filename,
 			Hash:     hashStr,
 			Error:    err,
 			IsFile:   true,
+			Size:     int64(len(data)),
 			Duration: time.Since(start),
-		}
+		})
 	}
 
 	// Stream-based hashing for regular algorithms
-	hashStr, err := computeFileHash(alg.NewHash(), filename)
-	return Result{
+	hashStr, size, cached, err := cachedFileHash(alg, filename, opts)
+	return observed(opts, Result{
 		Input:    filename,
 		Hash:     hashStr,
 		Error:    err,
 		IsFile:   true,
+		Size:     size,
+		Cached:   cached,
 		Duration: time.Since(start),
+	})
+}
+
+// HashReader computes the hash of a stream, labelling the result with name.
+// Password hashes are not supported since they need the whole input.
+func HashReader(name string, r io.Reader, opts Options) Result {
+	start := time.Now()
+
+	alg, ok := GetAlgorithm(opts.Algorithm)
+	if !ok {
+		return Result{
+			Input: name,
+			Error: fmt.Errorf("unknown algorithm: %s", opts.Algorithm),
+		}
 	}
+	if alg.IsPasswordHash {
+		return Result{
+			Input: name,
+			Error: fmt.Errorf("%s cannot hash streams", alg.Name),
+		}
+	}
+
+	hashStr, size, err := computeReaderHash(alg.NewHash(), r)
+	return observed(opts, Result{
+		Input:    name,
+		Hash:     hashStr,
+		Error:    err,
+		Size:     size,
+		Duration: time.Since(start),
+	})
 }
 
 // HashFiles computes hashes for multiple files in parallel while preserving order
@@ -168,6 +249,8 @@ func HashFiles(files []string, opts Options, onResult func(Result)) {
 
 			start := time.Now()
 			var hashStr string
+			var size int64
+			var cached bool
 			var err error
 
 			if alg.IsPasswordHash {
@@ -175,20 +258,22 @@ func HashFiles(files []string, opts Options, onResult func(Result)) {
 				if readErr != nil {
 					err = readErr
 				} else {
+					size = int64(len(data))
 					hashStr, err = hashPassword(string(data), opts)
 				}
 			} else {
-				h := alg.NewHash()
-				hashStr, err = computeFileHash(h, fname)
+				hashStr, size, cached, err = cachedFileHash(alg, fname, opts)
 			}
 
-			results[i] = Result{
+			results[i] = observed(opts, Result{
 				Input:    fname,
 				Hash:     hashStr,
 				Error:    err,
 				IsFile:   true,
+				Size:     size,
+				Cached:   cached,
 				Duration: time.Since(start),
-			}
+			})
 
 			// Try to print results in order
 			for {
@@ -226,20 +311,66 @@ func HashFiles(files []string, opts Options, onResult func(Result)) {
 	printMu.Unlock()
 }
 
+// observed reports r to opts.Observer and returns it unchanged
+func observed(opts Options, r Result) Result {
+	if opts.Observer != nil {
+		opts.Observer.Observe(opts.Algorithm, r)
+	}
+	return r
+}
+
+// cachedFileHash consults opts.Cache before streaming a file through the hash
+func cachedFileHash(alg Algorithm, fname string, opts Options) (string, int64, bool, error) {
+	if opts.Cache == nil {
+		hashStr, size, err := computeFileHash(alg.NewHash(), fname)
+		return hashStr, size, false, err
+	}
+
+	before, err := os.Stat(fname)
+	if err != nil {
+		return "", 0, false, err
+	}
+	if digest, ok := opts.Cache.Lookup(fname, before, opts.Algorithm); ok {
+		return digest, before.Size(), true, nil
+	}
+
+	hashStr, size, err := computeFileHash(alg.NewHash(), fname)
+	if err != nil {
+		return "", size, false, err
+	}
+
+	// Only remember digests of files that did not change while being read
+	after, err := os.Stat(fname)
+	if err == nil && after.Size() == before.Size() && after.ModTime().Equal(before.ModTime()) {
+		opts.Cache.Store(fname, after, opts.Algorithm, hashStr)
+	}
+	return hashStr, size, false, nil
+}
+
 // computeFileHash streams a file through the hash
-func computeFileHash(h hash.Hash, fname string) (string, error) {
+func computeFileHash(h hash.Hash, fname string) (string, int64, error) {
 	f, err := os.Open(fname)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 	defer f.Close()
 
-	_, err = io.Copy(h, f)
+	return computeReaderHash(h, f)
+}
+
+// computeReaderHash streams a reader through the hash
+func computeReaderHash(h hash.Hash, r io.Reader) (string, int64, error) {
+	n, err := io.Copy(h, r)
 	if err != nil {
-		return "", err
+		return "", n, err
 	}
 
-	return hex.EncodeToString(h.Sum(nil)), nil
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// argon2idKey derives an argon2id key with the cost parameters from opts
+func argon2idKey(password, salt []byte, opts Options) []byte {
+	return argon2.IDKey(password, salt, opts.Argon2Time, opts.Argon2Memory, opts.Argon2Lanes, opts.Argon2KeyLen)
 }
 
 // hashPassword handles password-specific hashing algorithms
@@ -255,13 +386,47 @@ func hashPassword(password string, opts Options) (string, error) {
 		// Generate a deterministic salt from the input for reproducible hashes
 		// In production, you'd want a random salt stored with the hash
 		salt := []byte("hashctl-argon2id-salt")
-		hash := argon2.IDKey([]byte(password), salt, opts.Argon2Time, opts.Argon2Memory, opts.Argon2Lanes, opts.Argon2KeyLen)
-		return hex.EncodeToString(hash), nil
+		return hex.EncodeToString(argon2idKey([]byte(password), salt, opts)), nil
 	default:
 		return "", fmt.Errorf("unsupported password hash: %s", opts.Algorithm)
 	}
 }
 
+// maxArgon2KeyLen bounds the key length VerifyPassword derives, so a
+// hostile hash cannot ask for an arbitrarily long key
+const maxArgon2KeyLen = 1024
+
+// VerifyPassword checks a password against a hash produced by HashString
+// with a password algorithm. Comparison is constant-time.
+func VerifyPassword(password, hashed string, opts Options) (bool, error) {
+	switch opts.Algorithm {
+	case "bcrypt":
+		err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password))
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		return err == nil, err
+	case "argon2id":
+		expected, err := hex.DecodeString(strings.TrimSpace(hashed))
+		if err != nil {
+			return false, fmt.Errorf("invalid argon2id hash: %w", err)
+		}
+		if len(expected) == 0 || len(expected) > maxArgon2KeyLen {
+			return false, fmt.Errorf("invalid argon2id hash: key must be 1 to %d bytes", maxArgon2KeyLen)
+		}
+		o := opts
+		o.Argon2KeyLen = uint32(len(expected))
+		actual, err := hashPassword(password, o)
+		if err != nil {
+			return false, err
+		}
+		got, _ := hex.DecodeString(actual)
+		return subtle.ConstantTimeCompare(got, expected) == 1, nil
+	default:
+		return false, fmt.Errorf("unsupported password hash: %s", opts.Algorithm)
+	}
+}
+
 // GetFileSize returns the size of a file in bytes
 func GetFileSize(filename string) (int64, error) {
 	info, err := os.Stat(filename)
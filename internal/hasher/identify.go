@@ -0,0 +1,299 @@
+package hasher
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Candidate is a possible origin of a digest string
+type Candidate struct {
+	// Algorithm is the registry key, empty if hashctl cannot compute it
+	Algorithm string
+	Name      string
+	// Score ranks candidates from 0 to 100
+	Score  int
+	Reason string
+}
+
+// Likelihood describes the score in words
+func (c Candidate) Likelihood() string {
+	switch {
+	case c.Score >= 90:
+		return "certain"
+	case c.Score >= 70:
+		return "likely"
+	case c.Score >= 40:
+		return "possible"
+	default:
+		return "unlikely"
+	}
+}
+
+// Identification is the result of classifying a digest string
+type Identification struct {
+	Input    string
+	Encoding string // e.g. "hex", "base64", "modular crypt", "multihash"
+	// Bytes is the decoded digest length, zero when unknown
+	Bytes      int
+	Candidates []Candidate
+}
+
+// commonness weighs same-length algorithms by how often they are seen in
+// the wild
+var commonness = map[string]int{
+	"crc32":       80,
+	"md5":         85,
+	"sha1":        85,
+	"ripemd160":   35,
+	"sha224":      60,
+	"sha3-224":    30,
+	"sha512-224":  20,
+	"sha256":      85,
+	"sha3-256":    45,
+	"blake2b-256": 40,
+	"blake2s-256": 35,
+	"sha512-256":  30,
+	"sha384":      75,
+	"sha3-384":    35,
+	"blake2b-384": 25,
+	"sha512":      80,
+	"sha3-512":    40,
+	"blake2b-512": 40,
+}
+
+// cryptPrefixes are modular crypt formats, mapped to registry keys where
+// hashctl can produce them
+var cryptPrefixes = []struct {
+	prefix, algorithm, name string
+}{
+	{"$2a$", "bcrypt", "bcrypt"},
+	{"$2b$", "bcrypt", "bcrypt"},
+	{"$2y$", "bcrypt", "bcrypt"},
+	{"$2x$", "bcrypt", "bcrypt"},
+	{"$argon2id$", "argon2id", "Argon2id"},
+	{"$argon2i$", "", "Argon2i"},
+	{"$argon2d$", "", "Argon2d"},
+	{"$1$", "", "MD5-crypt"},
+	{"$5$", "", "SHA-256-crypt"},
+	{"$6$", "", "SHA-512-crypt"},
+	{"$y$", "", "yescrypt"},
+	{"$7$", "", "scrypt"},
+	{"$scrypt$", "", "scrypt"},
+	{"$pbkdf2-sha256$", "", "PBKDF2-SHA256"},
+	{"$pbkdf2-sha512$", "", "PBKDF2-SHA512"},
+}
+
+var (
+	hexPattern    = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+	base64Pattern = regexp.MustCompile(`^[A-Za-z0-9+/_-]+={0,2}$`)
+	bcryptPattern = regexp.MustCompile(`^\$2[abxy]\$\d\d\$[./A-Za-z0-9]{53}$`)
+	labelPattern  = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]{0,15}$`)
+)
+
+// Identify guesses which algorithms could have produced a digest string.
+// Structured formats (modular crypt, "sha256:…" and SRI "sha384-…"
+// prefixes, multihashes and CIDs) are recognised exactly; bare hex and
+// base64 digests are matched by length and ranked by how common each
+// algorithm is.
+func Identify(s string) Identification {
+	s = strings.TrimSpace(s)
+	id := Identification{Input: s}
+	if s == "" {
+		return id
+	}
+
+	if strings.HasPrefix(s, "$") {
+		identifyCrypt(&id)
+		return id
+	}
+
+	if algo, rest, ok := strings.Cut(s, ":"); ok && isAlgorithmLabel(algo) {
+		if identifyLabelled(&id, algo, rest, "hex", "prefixed digest") {
+			return id
+		}
+	}
+	for _, sri := range []string{"sha256", "sha384", "sha512"} {
+		if rest, ok := strings.CutPrefix(s, sri+"-"); ok {
+			if identifyLabelled(&id, sri, rest, "base64", "subresource integrity") {
+				return id
+			}
+		}
+	}
+
+	if hexPattern.MatchString(s) && len(s)%2 == 0 {
+		data, _ := hex.DecodeString(s)
+		// Plenty of plain digests happen to parse as a multihash; only a
+		// full-length digest for the code counts
+		if mh, err := DecodeMultihash(data); err == nil && mh.Algorithm != "" &&
+			len(mh.Digest) == Registry[mh.Algorithm].NewHash().Size() {
+			id.Encoding = "multihash"
+			id.Bytes = len(mh.Digest)
+			id.Candidates = []Candidate{{
+				Algorithm: mh.Algorithm,
+				Name:      Registry[mh.Algorithm].Name,
+				Score:     95,
+				Reason:    fmt.Sprintf("multihash code 0x%x", mh.Code),
+			}}
+			return id
+		}
+		id.Encoding = "hex"
+		id.Bytes = len(data)
+		id.Candidates = lengthCandidates(len(data), 0)
+		if len(data) == 16 {
+			id.Candidates = append(id.Candidates, Candidate{Name: "NTLM", Score: 30, Reason: "32 hex characters"})
+		}
+		if len(data) == 32 {
+			id.Candidates = append(id.Candidates, Candidate{
+				Algorithm: "argon2id", Name: Registry["argon2id"].Name, Score: 15,
+				Reason: "hashctl prints raw argon2id keys as 32-byte hex",
+			})
+		}
+		sortCandidates(id.Candidates)
+		return id
+	}
+
+	if c, err := ParseContentID(s); err == nil {
+		id.Encoding = "cid"
+		id.Bytes = len(c.Multihash.Digest)
+		if c.Multihash.Algorithm != "" {
+			id.Candidates = []Candidate{{
+				Algorithm: c.Multihash.Algorithm,
+				Name:      Registry[c.Multihash.Algorithm].Name,
+				Score:     98,
+				Reason:    fmt.Sprintf("CIDv%d with multihash code 0x%x", c.Version, c.Multihash.Code),
+			}}
+		}
+		return id
+	}
+
+	if base64Pattern.MatchString(s) {
+		// Length matches are weaker evidence for base64 than for hex
+		if data, ok := decodeBase64(s); ok {
+			if c := lengthCandidates(len(data), -15); len(c) > 0 {
+				id.Encoding = "base64"
+				id.Bytes = len(data)
+				id.Candidates = c
+				sortCandidates(id.Candidates)
+				return id
+			}
+		}
+	}
+
+	id.Encoding = "unknown"
+	return id
+}
+
+// identifyCrypt classifies modular crypt strings such as "$2b$10$…"
+func identifyCrypt(id *Identification) {
+	id.Encoding = "modular crypt"
+	for _, p := range cryptPrefixes {
+		if !strings.HasPrefix(id.Input, p.prefix) {
+			continue
+		}
+		c := Candidate{Algorithm: p.algorithm, Name: p.name, Score: 95, Reason: p.prefix + " prefix"}
+		if p.algorithm == "bcrypt" && !bcryptPattern.MatchString(id.Input) {
+			c.Score = 60
+			c.Reason += ", but malformed"
+		}
+		id.Candidates = []Candidate{c}
+		return
+	}
+}
+
+// identifyLabelled handles digests that name their algorithm, such as
+// "sha256:<hex>" or "sha384-<base64>"
+func identifyLabelled(id *Identification, label, digest, encoding, reason string) bool {
+	var data []byte
+	var ok bool
+	if encoding == "hex" {
+		var err error
+		data, err = hex.DecodeString(digest)
+		ok = err == nil
+	} else {
+		data, ok = decodeBase64(digest)
+	}
+	if !ok {
+		return false
+	}
+
+	key := normalizeLabel(label)
+	id.Encoding = encoding
+	id.Bytes = len(data)
+
+	alg, known := Registry[key]
+	c := Candidate{Name: label, Score: 60, Reason: reason + " with unknown algorithm"}
+	if known {
+		c = Candidate{Algorithm: key, Name: alg.Name, Score: 98, Reason: reason}
+		if !alg.IsPasswordHash && alg.NewHash().Size() != len(data) {
+			c.Score = 50
+			c.Reason = fmt.Sprintf("%s, but %d bytes instead of %d", reason, len(data), alg.NewHash().Size())
+		}
+	}
+	id.Candidates = []Candidate{c}
+	return true
+}
+
+// lengthCandidates lists registry algorithms producing n-byte digests
+func lengthCandidates(n, adjust int) []Candidate {
+	var out []Candidate
+	for _, key := range ListNames() {
+		alg := Registry[key]
+		if alg.IsPasswordHash || alg.NewHash().Size() != n {
+			continue
+		}
+		score := commonness[key]
+		if score == 0 {
+			score = 30
+		}
+		out = append(out, Candidate{
+			Algorithm: key,
+			Name:      alg.Name,
+			Score:     max(min(score+adjust, 100), 1),
+			Reason:    fmt.Sprintf("%d-byte digest", n),
+		})
+	}
+	return out
+}
+
+func sortCandidates(c []Candidate) {
+	sort.SliceStable(c, func(i, j int) bool {
+		if c[i].Score != c[j].Score {
+			return c[i].Score > c[j].Score
+		}
+		return c[i].Name < c[j].Name
+	})
+}
+
+// isAlgorithmLabel reports whether s looks like an algorithm name prefix
+func isAlgorithmLabel(s string) bool {
+	return labelPattern.MatchString(s)
+}
+
+// normalizeLabel maps spellings like "SHA-256" or "sha3_256" to registry keys
+func normalizeLabel(label string) string {
+	key := strings.ToLower(strings.ReplaceAll(label, "_", "-"))
+	if _, ok := Registry[key]; ok {
+		return key
+	}
+	if alt := strings.Replace(key, "sha-", "sha", 1); Registry[alt].Name != "" {
+		return alt
+	}
+	return key
+}
+
+// decodeBase64 accepts standard and URL-safe base64, padded or not
+func decodeBase64(s string) ([]byte, bool) {
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding, base64.RawStdEncoding,
+		base64.URLEncoding, base64.RawURLEncoding,
+	} {
+		if data, err := enc.DecodeString(s); err == nil {
+			return data, true
+		}
+	}
+	return nil, false
+}
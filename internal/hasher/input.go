@@ -0,0 +1,125 @@
+package hasher
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Input decoders turn typed text into the exact bytes to hash
+const (
+	DecodeText   = "text"
+	DecodeHex    = "hex"
+	DecodeBase64 = "base64"
+	DecodeEscape = "escape"
+)
+
+// Decoders lists the supported input decoders
+var Decoders = []string{DecodeText, DecodeHex, DecodeBase64, DecodeEscape}
+
+// Line endings for multi-line text
+const (
+	LineEndingLF   = "lf"
+	LineEndingCRLF = "crlf"
+	LineEndingNone = "none"
+)
+
+// LineEndings lists the supported line endings
+var LineEndings = []string{LineEndingLF, LineEndingCRLF, LineEndingNone}
+
+// DecodeInput converts s to bytes with the named decoder. Hex and base64
+// ignore whitespace so pasted dumps work; escape understands Go and C
+// sequences such as \n, \t, \0, \xff and \u00e9.
+func DecodeInput(s, decoder string) ([]byte, error) {
+	switch decoder {
+	case "", DecodeText:
+		return []byte(s), nil
+	case DecodeHex:
+		s = strings.Join(strings.Fields(s), "")
+		s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+		data, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex input: %w", err)
+		}
+		return data, nil
+	case DecodeBase64:
+		data, ok := decodeBase64(strings.Join(strings.Fields(s), ""))
+		if !ok {
+			return nil, errors.New("invalid base64 input")
+		}
+		return data, nil
+	case DecodeEscape:
+		return unescape(s)
+	default:
+		return nil, fmt.Errorf("unknown input decoder: %s", decoder)
+	}
+}
+
+// unescape expands backslash escapes, keeping \xHH as a raw byte
+func unescape(s string) ([]byte, error) {
+	var out []byte
+	for len(s) > 0 {
+		if s[0] != '\\' {
+			out = append(out, s[0])
+			s = s[1:]
+			continue
+		}
+		if len(s) == 1 {
+			return nil, errors.New("invalid escape input: trailing backslash")
+		}
+
+		switch s[1] {
+		case '"', '\'':
+			out = append(out, s[1])
+			s = s[2:]
+			continue
+		case 'e':
+			out = append(out, 0x1b)
+			s = s[2:]
+			continue
+		case '0':
+			// A lone \0 is NUL as in C; \0NN is octal
+			if len(s) < 4 || !isOctal(s[2]) || !isOctal(s[3]) {
+				out = append(out, 0)
+				s = s[2:]
+				continue
+			}
+		}
+
+		value, multibyte, tail, err := strconv.UnquoteChar(s, 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid escape input near %q", s[:min(len(s), 4)])
+		}
+		if multibyte {
+			out = utf8.AppendRune(out, value)
+		} else {
+			out = append(out, byte(value))
+		}
+		s = tail
+	}
+	return out, nil
+}
+
+func isOctal(c byte) bool {
+	return c >= '0' && c <= '7'
+}
+
+// ApplyLineEnding rewrites every line break in s as the given ending;
+// "none" joins the lines without separators
+func ApplyLineEnding(s, ending string) (string, error) {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	switch ending {
+	case "", LineEndingLF:
+		return s, nil
+	case LineEndingCRLF:
+		return strings.ReplaceAll(s, "\n", "\r\n"), nil
+	case LineEndingNone:
+		return strings.ReplaceAll(s, "\n", ""), nil
+	default:
+		return "", fmt.Errorf("unknown line ending: %s", ending)
+	}
+}
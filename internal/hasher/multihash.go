@@ -0,0 +1,240 @@
+package hasher
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Multiformat codes used when building CIDs
+const (
+	CIDVersion1 = 1
+	CodecRaw    = 0x55
+	CodecDagPB  = 0x70
+
+	// DefaultChunkSize is the block size `ipfs add` splits files at. Files up
+	// to this size are stored as a single raw leaf block.
+	DefaultChunkSize = 256 * 1024
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base32Lower = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// ErrBlockTooLarge is returned when a file does not fit in a single raw block
+var ErrBlockTooLarge = errors.New("input exceeds single-block size")
+
+// Multihash is a decoded multihash value
+type Multihash struct {
+	Code      uint64
+	Algorithm string // registry key, empty if the code is not registered
+	Digest    []byte
+}
+
+// CID is a decoded content identifier
+type CID struct {
+	Version   uint64
+	Codec     uint64
+	Multihash Multihash
+}
+
+// AlgorithmForCode returns the registry key for a multihash code
+func AlgorithmForCode(code uint64) (string, bool) {
+	for key, alg := range Registry {
+		if alg.Multicodec != 0 && alg.Multicodec == code {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// EncodeMultihash wraps a raw digest as <varint code><varint length><digest>
+func EncodeMultihash(algorithm string, digest []byte) ([]byte, error) {
+	alg, ok := GetAlgorithm(algorithm)
+	if !ok {
+		return nil, fmt.Errorf("unknown algorithm: %s", algorithm)
+	}
+	if alg.Multicodec == 0 {
+		return nil, fmt.Errorf("%s has no multihash code", alg.Name)
+	}
+
+	buf := make([]byte, 0, 2*binary.MaxVarintLen64+len(digest))
+	buf = binary.AppendUvarint(buf, alg.Multicodec)
+	buf = binary.AppendUvarint(buf, uint64(len(digest)))
+	return append(buf, digest...), nil
+}
+
+// DecodeMultihash parses a binary multihash
+func DecodeMultihash(data []byte) (Multihash, error) {
+	code, n := binary.Uvarint(data)
+	if n <= 0 {
+		return Multihash{}, errors.New("invalid multihash: bad code varint")
+	}
+	data = data[n:]
+
+	length, n := binary.Uvarint(data)
+	if n <= 0 {
+		return Multihash{}, errors.New("invalid multihash: bad length varint")
+	}
+	data = data[n:]
+
+	if uint64(len(data)) != length {
+		return Multihash{}, fmt.Errorf("invalid multihash: expected %d digest bytes, got %d", length, len(data))
+	}
+
+	algorithm, _ := AlgorithmForCode(code)
+	return Multihash{
+		Code:      code,
+		Algorithm: algorithm,
+		Digest:    data,
+	}, nil
+}
+
+// EncodeCIDv1 builds a binary CIDv1 for the given codec and multihash
+func EncodeCIDv1(codec uint64, multihash []byte) []byte {
+	buf := make([]byte, 0, 2*binary.MaxVarintLen64+len(multihash))
+	buf = binary.AppendUvarint(buf, CIDVersion1)
+	buf = binary.AppendUvarint(buf, codec)
+	return append(buf, multihash...)
+}
+
+// EncodeCIDString renders a binary CID in its canonical string form
+// (base32 multibase for v1, bare base58btc for v0)
+func EncodeCIDString(cid []byte) string {
+	if len(cid) == 34 && cid[0] == 0x12 && cid[1] == 0x20 {
+		return Base58Encode(cid)
+	}
+	return "b" + base32Lower.EncodeToString(cid)
+}
+
+// RawLeafCID returns the CIDv1 of data stored as a single raw block
+func RawLeafCID(algorithm string, digest []byte) (string, error) {
+	mh, err := EncodeMultihash(algorithm, digest)
+	if err != nil {
+		return "", err
+	}
+	return EncodeCIDString(EncodeCIDv1(CodecRaw, mh)), nil
+}
+
+// DecodeCID parses a binary CID. CIDv0 values are bare sha2-256 multihashes.
+func DecodeCID(data []byte) (CID, error) {
+	if len(data) == 34 && data[0] == 0x12 && data[1] == 0x20 {
+		mh, err := DecodeMultihash(data)
+		return CID{Version: 0, Codec: CodecDagPB, Multihash: mh}, err
+	}
+
+	version, n := binary.Uvarint(data)
+	if n <= 0 {
+		return CID{}, errors.New("invalid CID: bad version varint")
+	}
+	if version != CIDVersion1 {
+		return CID{}, fmt.Errorf("unsupported CID version: %d", version)
+	}
+	data = data[n:]
+
+	codec, n := binary.Uvarint(data)
+	if n <= 0 {
+		return CID{}, errors.New("invalid CID: bad codec varint")
+	}
+
+	mh, err := DecodeMultihash(data[n:])
+	if err != nil {
+		return CID{}, err
+	}
+	return CID{Version: version, Codec: codec, Multihash: mh}, nil
+}
+
+// ParseContentID decodes a textual multihash or CID. Accepted forms are
+// base32 ("b…") and base16 ("f…") multibase CIDs, base58btc CIDv0 ("Qm…"),
+// and hex or base58btc encoded multihashes.
+func ParseContentID(s string) (CID, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return CID{}, errors.New("empty input")
+	}
+
+	if strings.HasPrefix(s, "Qm") && len(s) == 46 {
+		data, err := Base58Decode(s)
+		if err != nil {
+			return CID{}, err
+		}
+		return DecodeCID(data)
+	}
+
+	if data, err := hex.DecodeString(s); err == nil {
+		mh, err := DecodeMultihash(data)
+		return CID{Multihash: mh}, err
+	}
+
+	var data []byte
+	var err error
+	switch s[0] {
+	case 'b':
+		data, err = base32Lower.DecodeString(s[1:])
+	case 'B':
+		data, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s[1:])
+	case 'f', 'F':
+		data, err = hex.DecodeString(s[1:])
+	case 'z':
+		data, err = Base58Decode(s[1:])
+	default:
+		data, err = Base58Decode(s)
+		if err == nil {
+			mh, err := DecodeMultihash(data)
+			return CID{Multihash: mh}, err
+		}
+	}
+	if err != nil {
+		return CID{}, fmt.Errorf("invalid encoding: %w", err)
+	}
+	return DecodeCID(data)
+}
+
+// Base58Encode encodes bytes using the bitcoin base58 alphabet
+func Base58Encode(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// Base58Decode decodes a bitcoin base58 string
+func Base58Decode(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+
+	for _, c := range s {
+		idx := strings.IndexRune(base58Alphabet, c)
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
@@ -0,0 +1,102 @@
+package hasher
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestMultihashRoundTrip(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		prefix    string // code and length varints
+	}{
+		{"sha1", "1114"},
+		{"sha256", "1220"},
+		{"sha512", "1340"},
+		{"sha3-256", "1620"},
+		{"blake2b-256", "a0e40220"},
+		{"blake2s-256", "e0e40220"},
+		{"md5", "d50110"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Algorithm = tt.algorithm
+			r := HashString("hello world", opts)
+			if r.Error != nil {
+				t.Fatalf("HashString: %v", r.Error)
+			}
+			digest, _ := hex.DecodeString(r.Hash)
+
+			mh, err := EncodeMultihash(tt.algorithm, digest)
+			if err != nil {
+				t.Fatalf("EncodeMultihash: %v", err)
+			}
+			if got := hex.EncodeToString(mh); got != tt.prefix+r.Hash {
+				t.Errorf("EncodeMultihash = %s, want %s%s", got, tt.prefix, r.Hash)
+			}
+
+			decoded, err := DecodeMultihash(mh)
+			if err != nil {
+				t.Fatalf("DecodeMultihash: %v", err)
+			}
+			if decoded.Algorithm != tt.algorithm || !bytes.Equal(decoded.Digest, digest) {
+				t.Errorf("DecodeMultihash = %s %x, want %s %x", decoded.Algorithm, decoded.Digest, tt.algorithm, digest)
+			}
+
+			cid, err := ParseContentID(hex.EncodeToString(mh))
+			if err != nil {
+				t.Fatalf("ParseContentID: %v", err)
+			}
+			if cid.Multihash.Algorithm != tt.algorithm || !bytes.Equal(cid.Multihash.Digest, digest) {
+				t.Errorf("ParseContentID = %s %x, want %s %x", cid.Multihash.Algorithm, cid.Multihash.Digest, tt.algorithm, digest)
+			}
+		})
+	}
+}
+
+func TestDecodeMultihashErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"no length", "12"},
+		{"short digest", "1220b94d27"},
+		{"long digest", "1204b94d27b9ff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tt.data)
+			if _, err := DecodeMultihash(data); err == nil {
+				t.Errorf("DecodeMultihash(%s) succeeded, want error", tt.data)
+			}
+		})
+	}
+}
+
+func TestRawLeafCID(t *testing.T) {
+	// `echo -n "hello world" | ipfs add --raw-leaves --cid-version=1`
+	const want = "bafkreifzjut3te2nhyekklss27nh3k72ysco7y32koao5eei66wof36n5e"
+
+	opts := DefaultOptions()
+	opts.Algorithm = "sha256"
+	digest, _ := hex.DecodeString(HashString("hello world", opts).Hash)
+
+	got, err := RawLeafCID("sha256", digest)
+	if err != nil {
+		t.Fatalf("RawLeafCID: %v", err)
+	}
+	if got != want {
+		t.Errorf("RawLeafCID = %s, want %s", got, want)
+	}
+
+	cid, err := ParseContentID(want)
+	if err != nil {
+		t.Fatalf("ParseContentID: %v", err)
+	}
+	if cid.Version != CIDVersion1 || cid.Codec != CodecRaw || cid.Multihash.Algorithm != "sha256" || !bytes.Equal(cid.Multihash.Digest, digest) {
+		t.Errorf("ParseContentID = %+v", cid)
+	}
+}
@@ -0,0 +1,127 @@
+package hasher
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Status is an algorithm's standing under a Policy
+type Status int
+
+const (
+	StatusApproved Status = iota
+	StatusDeprecated
+	StatusForbidden
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusApproved:
+		return "approved"
+	case StatusDeprecated:
+		return "deprecated"
+	case StatusForbidden:
+		return "forbidden"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrForbidden is returned by Policy.Check for forbidden algorithms
+var ErrForbidden = errors.New("forbidden by policy")
+
+// Policy decides which algorithms may be used. Deprecated algorithms remain
+// usable but should be reported to the user.
+type Policy struct {
+	Name        string
+	Description string
+	// Default applies to algorithms without an entry in Rules
+	Default Status
+	Rules   map[string]Status
+}
+
+// DefaultPolicy allows every algorithm and only flags the broken ones
+const DefaultPolicy = "legacy"
+
+// Policies holds the built-in policies
+var Policies = map[string]Policy{
+	"legacy": {
+		Name:        "legacy",
+		Description: "Every algorithm is allowed; MD5 and SHA-1 are flagged as deprecated.",
+		Default:     StatusApproved,
+		Rules: map[string]Status{
+			"md5":  StatusDeprecated,
+			"sha1": StatusDeprecated,
+		},
+	},
+	"strict": {
+		Name:        "strict",
+		Description: "Modern hashes only; broken algorithms and checksums are refused.",
+		Default:     StatusApproved,
+		Rules: map[string]Status{
+			"crc32":      StatusForbidden,
+			"md5":        StatusForbidden,
+			"sha1":       StatusForbidden,
+			"sha224":     StatusDeprecated,
+			"sha512-224": StatusDeprecated,
+			"ripemd160":  StatusDeprecated,
+			"bcrypt":     StatusDeprecated,
+		},
+	},
+	"fips": {
+		Name:        "fips",
+		Description: "Only FIPS 180-4 and FIPS 202 hashes; SHA-1 is deprecated (SP 800-131A).",
+		Default:     StatusForbidden,
+		Rules: map[string]Status{
+			"sha1":       StatusDeprecated,
+			"sha224":     StatusApproved,
+			"sha256":     StatusApproved,
+			"sha384":     StatusApproved,
+			"sha512":     StatusApproved,
+			"sha512-224": StatusApproved,
+			"sha512-256": StatusApproved,
+			"sha3-224":   StatusApproved,
+			"sha3-256":   StatusApproved,
+			"sha3-384":   StatusApproved,
+			"sha3-512":   StatusApproved,
+		},
+	},
+}
+
+// GetPolicy returns a built-in policy by name
+func GetPolicy(name string) (Policy, bool) {
+	p, ok := Policies[name]
+	return p, ok
+}
+
+// PolicyNames returns all policy names
+func PolicyNames() []string {
+	var names []string
+	for name := range Policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Status returns the standing of an algorithm key under the policy
+func (p Policy) Status(algorithm string) Status {
+	if s, ok := p.Rules[algorithm]; ok {
+		return s
+	}
+	return p.Default
+}
+
+// Check returns an error wrapping ErrForbidden if the algorithm is forbidden
+func (p Policy) Check(algorithm string) error {
+	if p.Status(algorithm) == StatusForbidden {
+		return fmt.Errorf("%s is %w %s", algorithm, ErrForbidden, p.Name)
+	}
+	return nil
+}
+
+// Allowed reports whether an algorithm may be used
+func (p Policy) Allowed(algorithm string) bool {
+	return p.Status(algorithm) != StatusForbidden
+}
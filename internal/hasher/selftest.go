@@ -0,0 +1,275 @@
+package hasher
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// KnownAnswer is a reference test vector for one algorithm
+type KnownAnswer struct {
+	Algorithm string
+	Name      string // short label for the message, e.g. "million-a"
+	Message   string
+	Repeat    int // the message is repeated this many times; 0 means once
+	// Digest is the expected hex digest, or a reference hash string for bcrypt
+	Digest string
+	// Argon2 holds the salt and cost parameters of argon2id vectors
+	Argon2 *Argon2Params
+}
+
+// Argon2Params are the inputs of an argon2id vector besides the password
+type Argon2Params struct {
+	Salt   string
+	Time   uint32
+	Memory uint32 // KiB
+	Lanes  uint8
+}
+
+// Input returns the vector's message bytes
+func (k KnownAnswer) Input() []byte {
+	if k.Repeat > 0 {
+		return []byte(strings.Repeat(k.Message, k.Repeat))
+	}
+	return []byte(k.Message)
+}
+
+// KnownAnswers are NIST, RFC and reference-implementation vectors for every
+// algorithm in Registry: the empty string, "abc" and one million 'a'
+// characters for digests, the Openwall bcrypt vectors and the PHC argon2id
+// reference vectors.
+var KnownAnswers = []KnownAnswer{
+	// crc32
+	{Algorithm: "crc32", Name: "empty", Message: "",
+		Digest: "00000000"},
+	{Algorithm: "crc32", Name: "abc", Message: "abc",
+		Digest: "352441c2"},
+	{Algorithm: "crc32", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "dc25bfbc"},
+	// md5
+	{Algorithm: "md5", Name: "empty", Message: "",
+		Digest: "d41d8cd98f00b204e9800998ecf8427e"},
+	{Algorithm: "md5", Name: "abc", Message: "abc",
+		Digest: "900150983cd24fb0d6963f7d28e17f72"},
+	{Algorithm: "md5", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "7707d6ae4e027c70eea2a935c2296f21"},
+	// sha1
+	{Algorithm: "sha1", Name: "empty", Message: "",
+		Digest: "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+	{Algorithm: "sha1", Name: "abc", Message: "abc",
+		Digest: "a9993e364706816aba3e25717850c26c9cd0d89d"},
+	{Algorithm: "sha1", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "34aa973cd4c4daa4f61eeb2bdbad27316534016f"},
+	// sha224
+	{Algorithm: "sha224", Name: "empty", Message: "",
+		Digest: "d14a028c2a3a2bc9476102bb288234c415a2b01f828ea62ac5b3e42f"},
+	{Algorithm: "sha224", Name: "abc", Message: "abc",
+		Digest: "23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7"},
+	{Algorithm: "sha224", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "20794655980c91d8bbb4c1ea97618a4bf03f42581948b2ee4ee7ad67"},
+	// sha256
+	{Algorithm: "sha256", Name: "empty", Message: "",
+		Digest: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+	{Algorithm: "sha256", Name: "abc", Message: "abc",
+		Digest: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	{Algorithm: "sha256", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "cdc76e5c9914fb9281a1c7e284d73e67f1809a48a497200e046d39ccc7112cd0"},
+	// sha384
+	{Algorithm: "sha384", Name: "empty", Message: "",
+		Digest: "38b060a751ac96384cd9327eb1b1e36a21fdb71114be07434c0cc7bf63f6e1da274edebfe76f65fbd51ad2f14898b95b"},
+	{Algorithm: "sha384", Name: "abc", Message: "abc",
+		Digest: "cb00753f45a35e8bb5a03d699ac65007272c32ab0eded1631a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7"},
+	{Algorithm: "sha384", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "9d0e1809716474cb086e834e310a4a1ced149e9c00f248527972cec5704c2a5b07b8b3dc38ecc4ebae97ddd87f3d8985"},
+	// sha512
+	{Algorithm: "sha512", Name: "empty", Message: "",
+		Digest: "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e"},
+	{Algorithm: "sha512", Name: "abc", Message: "abc",
+		Digest: "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+	{Algorithm: "sha512", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "e718483d0ce769644e2e42c7bc15b4638e1f98b13b2044285632a803afa973ebde0ff244877ea60a4cb0432ce577c31beb009c5c2c49aa2e4eadb217ad8cc09b"},
+	// sha512-224
+	{Algorithm: "sha512-224", Name: "empty", Message: "",
+		Digest: "6ed0dd02806fa89e25de060c19d3ac86cabb87d6a0ddd05c333b84f4"},
+	{Algorithm: "sha512-224", Name: "abc", Message: "abc",
+		Digest: "4634270f707b6a54daae7530460842e20e37ed265ceee9a43e8924aa"},
+	{Algorithm: "sha512-224", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "37ab331d76f0d36de422bd0edeb22a28accd487b7a8453ae965dd287"},
+	// sha512-256
+	{Algorithm: "sha512-256", Name: "empty", Message: "",
+		Digest: "c672b8d1ef56ed28ab87c3622c5114069bdd3ad7b8f9737498d0c01ecef0967a"},
+	{Algorithm: "sha512-256", Name: "abc", Message: "abc",
+		Digest: "53048e2681941ef99b2e29b76b4c7dabe4c2d0c634fc6d46e0e2f13107e7af23"},
+	{Algorithm: "sha512-256", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "9a59a052930187a97038cae692f30708aa6491923ef5194394dc68d56c74fb21"},
+	// sha3-224
+	{Algorithm: "sha3-224", Name: "empty", Message: "",
+		Digest: "6b4e03423667dbb73b6e15454f0eb1abd4597f9a1b078e3f5b5a6bc7"},
+	{Algorithm: "sha3-224", Name: "abc", Message: "abc",
+		Digest: "e642824c3f8cf24ad09234ee7d3c766fc9a3a5168d0c94ad73b46fdf"},
+	{Algorithm: "sha3-224", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "d69335b93325192e516a912e6d19a15cb51c6ed5c15243e7a7fd653c"},
+	// sha3-256
+	{Algorithm: "sha3-256", Name: "empty", Message: "",
+		Digest: "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a"},
+	{Algorithm: "sha3-256", Name: "abc", Message: "abc",
+		Digest: "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532"},
+	{Algorithm: "sha3-256", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "5c8875ae474a3634ba4fd55ec85bffd661f32aca75c6d699d0cdcb6c115891c1"},
+	// sha3-384
+	{Algorithm: "sha3-384", Name: "empty", Message: "",
+		Digest: "0c63a75b845e4f7d01107d852e4c2485c51a50aaaa94fc61995e71bbee983a2ac3713831264adb47fb6bd1e058d5f004"},
+	{Algorithm: "sha3-384", Name: "abc", Message: "abc",
+		Digest: "ec01498288516fc926459f58e2c6ad8df9b473cb0fc08c2596da7cf0e49be4b298d88cea927ac7f539f1edf228376d25"},
+	{Algorithm: "sha3-384", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "eee9e24d78c1855337983451df97c8ad9eedf256c6334f8e948d252d5e0e76847aa0774ddb90a842190d2c558b4b8340"},
+	// sha3-512
+	{Algorithm: "sha3-512", Name: "empty", Message: "",
+		Digest: "a69f73cca23a9ac5c8b567dc185a756e97c982164fe25859e0d1dcc1475c80a615b2123af1f5f94c11e3e9402c3ac558f500199d95b6d3e301758586281dcd26"},
+	{Algorithm: "sha3-512", Name: "abc", Message: "abc",
+		Digest: "b751850b1a57168a5693cd924b6b096e08f621827444f70d884f5d0240d2712e10e116e9192af3c91a7ec57647e3934057340b4cf408d5a56592f8274eec53f0"},
+	{Algorithm: "sha3-512", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "3c3a876da14034ab60627c077bb98f7e120a2a5370212dffb3385a18d4f38859ed311d0a9d5141ce9cc5c66ee689b266a8aa18ace8282a0e0db596c90b0a7b87"},
+	// ripemd160
+	{Algorithm: "ripemd160", Name: "empty", Message: "",
+		Digest: "9c1185a5c5e9fc54612808977ee8f548b2258d31"},
+	{Algorithm: "ripemd160", Name: "abc", Message: "abc",
+		Digest: "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc"},
+	{Algorithm: "ripemd160", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "52783243c1697bdbe16d37f97f68f08325dc1528"},
+	// blake2b-256
+	{Algorithm: "blake2b-256", Name: "empty", Message: "",
+		Digest: "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8"},
+	{Algorithm: "blake2b-256", Name: "abc", Message: "abc",
+		Digest: "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319"},
+	{Algorithm: "blake2b-256", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "0741850f36cba4259628355d1073e24ddb9ca0e1bfac36fd39ae5dc2101e23a4"},
+	// blake2b-384
+	{Algorithm: "blake2b-384", Name: "empty", Message: "",
+		Digest: "b32811423377f52d7862286ee1a72ee540524380fda1724a6f25d7978c6fd3244a6caf0498812673c5e05ef583825100"},
+	{Algorithm: "blake2b-384", Name: "abc", Message: "abc",
+		Digest: "6f56a82c8e7ef526dfe182eb5212f7db9df1317e57815dbda46083fc30f54ee6c66ba83be64b302d7cba6ce15bb556f4"},
+	{Algorithm: "blake2b-384", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "92650b7746765a98701ec2077c3603127c62525c8543477c8519d6cc53ac5a9f0098ed56eb7aaf03ca50bfe046e7bba3"},
+	// blake2b-512
+	{Algorithm: "blake2b-512", Name: "empty", Message: "",
+		Digest: "786a02f742015903c6c6fd852552d272912f4740e15847618a86e217f71f5419d25e1031afee585313896444934eb04b903a685b1448b755d56f701afe9be2ce"},
+	{Algorithm: "blake2b-512", Name: "abc", Message: "abc",
+		Digest: "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+	{Algorithm: "blake2b-512", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "98fb3efb7206fd19ebf69b6f312cf7b64e3b94dbe1a17107913975a793f177e1d077609d7fba363cbba00d05f7aa4e4fa8715d6428104c0a75643b0ff3fd3eaf"},
+	// blake2s-256
+	{Algorithm: "blake2s-256", Name: "empty", Message: "",
+		Digest: "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9"},
+	{Algorithm: "blake2s-256", Name: "abc", Message: "abc",
+		Digest: "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982"},
+	{Algorithm: "blake2s-256", Name: "million-a", Message: "a", Repeat: 1000000,
+		Digest: "bec0c0e6cde5b67acb73b81f79a67a4079ae1c60dac9d2661af18e9f8b50dfa5"},
+	// bcrypt (Openwall crypt_blowfish)
+	{Algorithm: "bcrypt", Name: "empty", Message: "",
+		Digest: "$2a$05$CCCCCCCCCCCCCCCCCCCCC.7uG0VCzI2bS7j6ymqJi9CdcdxiRTWNy"},
+	{Algorithm: "bcrypt", Name: "U*U", Message: "U*U",
+		Digest: "$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW"},
+	{Algorithm: "bcrypt", Name: "U*U*", Message: "U*U*",
+		Digest: "$2a$05$CCCCCCCCCCCCCCCCCCCCC.VGOzA784oUp/Z0DY336zx7pLYAy0lwK"},
+	{Algorithm: "bcrypt", Name: "U*U*U", Message: "U*U*U",
+		Digest: "$2a$05$XXXXXXXXXXXXXXXXXXXXXOAcXxm9kjPGEMsLznoKqmqw7tc8WCx4a"},
+	// argon2id (PHC reference implementation, version 0x13)
+	{Algorithm: "argon2id", Name: "m=256", Message: "password",
+		Argon2: &Argon2Params{Salt: "somesalt", Time: 2, Memory: 256, Lanes: 1},
+		Digest: "9dfeb910e80bad0311fee20f9c0e2b12c17987b4cac90c2ef54d5b3021c68bfe"},
+	{Algorithm: "argon2id", Name: "m=65536", Message: "password",
+		Argon2: &Argon2Params{Salt: "somesalt", Time: 2, Memory: 65536, Lanes: 1},
+		Digest: "09316115d5cf24ed5a15a31a3ba326e5cf32edc24702987c02b6566f61913cf7"},
+}
+
+// SelfTestResult reports the known-answer tests of one algorithm
+type SelfTestResult struct {
+	Algorithm string
+	Passed    int
+	Failures  []string
+}
+
+// OK reports whether the algorithm has vectors and all of them passed
+func (r SelfTestResult) OK() bool {
+	return r.Passed > 0 && len(r.Failures) == 0
+}
+
+// SelfTest runs KnownAnswers and returns one result per Registry algorithm
+// in ListNames order. An algorithm without vectors fails.
+func SelfTest() []SelfTestResult {
+	byAlg := make(map[string]*SelfTestResult)
+	var results []SelfTestResult
+	for _, name := range ListNames() {
+		results = append(results, SelfTestResult{Algorithm: name})
+	}
+	for i := range results {
+		byAlg[results[i].Algorithm] = &results[i]
+	}
+
+	for _, k := range KnownAnswers {
+		r, ok := byAlg[k.Algorithm]
+		if !ok {
+			continue
+		}
+		if err := runKnownAnswer(k); err != nil {
+			r.Failures = append(r.Failures, fmt.Sprintf("%s: %v", k.Name, err))
+		} else {
+			r.Passed++
+		}
+	}
+
+	for i := range results {
+		if results[i].Passed == 0 && len(results[i].Failures) == 0 {
+			results[i].Failures = []string{"no test vectors"}
+		}
+	}
+	return results
+}
+
+// runKnownAnswer checks a single vector
+func runKnownAnswer(k KnownAnswer) error {
+	alg, ok := GetAlgorithm(k.Algorithm)
+	if !ok {
+		return fmt.Errorf("unknown algorithm: %s", k.Algorithm)
+	}
+	input := k.Input()
+
+	switch {
+	case k.Argon2 != nil:
+		opts := DefaultOptions()
+		opts.Argon2Time = k.Argon2.Time
+		opts.Argon2Memory = k.Argon2.Memory
+		opts.Argon2Lanes = k.Argon2.Lanes
+		opts.Argon2KeyLen = uint32(len(k.Digest) / 2)
+		got := hex.EncodeToString(argon2idKey(input, []byte(k.Argon2.Salt), opts))
+		return compareDigest(got, k.Digest)
+
+	case alg.IsPasswordHash:
+		opts := DefaultOptions()
+		opts.Algorithm = k.Algorithm
+		match, err := VerifyPassword(string(input), k.Digest, opts)
+		if err != nil {
+			return err
+		}
+		if !match {
+			return fmt.Errorf("reference hash rejected")
+		}
+		// A wrong password must not verify
+		if match, _ := VerifyPassword(string(input)+"x", k.Digest, opts); match {
+			return fmt.Errorf("wrong password accepted")
+		}
+		return nil
+	}
+
+	h := alg.NewHash()
+	h.Write(input)
+	return compareDigest(hex.EncodeToString(h.Sum(nil)), k.Digest)
+}
+
+func compareDigest(got, want string) error {
+	if got != want {
+		return fmt.Errorf("got %s, want %s", got, want)
+	}
+	return nil
+}
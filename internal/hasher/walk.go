@@ -0,0 +1,33 @@
+package hasher
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+// WalkFiles returns every regular file below root in lexical order.
+// Symlinks, devices and other special files are skipped.
+func WalkFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// HashDir recursively hashes every regular file below root in parallel,
+// reporting results in lexical path order
+func HashDir(root string, opts Options, onResult func(Result)) error {
+	files, err := WalkFiles(root)
+	if err != nil {
+		return err
+	}
+	HashFiles(files, opts, onResult)
+	return nil
+}
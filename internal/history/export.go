@@ -0,0 +1,62 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Export formats
+const (
+	FormatJSON      = "json"
+	FormatCSV       = "csv"
+	FormatChecksums = "sums"
+)
+
+// Formats lists the supported export formats
+var Formats = []string{FormatJSON, FormatCSV, FormatChecksums}
+
+// Export writes entries in the given format. The checksum format follows
+// the sha256sum layout and only includes files; it makes sense for one
+// algorithm at a time.
+func Export(w io.Writer, entries []Entry, format string) error {
+	switch format {
+	case FormatJSON:
+		if entries == nil {
+			entries = []Entry{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"time", "algorithm", "kind", "input", "digest", "size"})
+		for _, e := range entries {
+			cw.Write([]string{
+				e.Time.Format(time.RFC3339),
+				e.Algorithm,
+				e.Kind,
+				e.Input,
+				e.Digest,
+				strconv.FormatInt(e.Size, 10),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	case FormatChecksums:
+		for _, e := range entries {
+			if e.Kind != KindFile {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s  %s\n", e.Digest, e.Input); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown export format: %s", format)
+	}
+}
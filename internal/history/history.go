@@ -0,0 +1,330 @@
+// Package history records computed digests so they can be searched,
+// exported and re-verified later
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+)
+
+// Limit is how many of the newest entries are kept when the file is compacted
+const Limit = 1000
+
+// compactSize is the file size that triggers compaction
+const compactSize = 512 * 1024
+
+// Entry kinds. Password entries were written by earlier versions only.
+const (
+	KindFile     = "file"
+	KindString   = "string"
+	KindPassword = "password"
+)
+
+// Entry is one computed digest
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Algorithm string    `json:"algorithm"`
+	Kind      string    `json:"kind"`
+	// Input is the absolute file path. Strings are not stored, only their
+	// size; entries from earlier versions may still hold the text.
+	Input     string `json:"input,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// FromResult builds an entry for a successful result. Password hashes are
+// not recorded at all, since hashctl's argon2id uses a fixed salt and its
+// digests could be used to test guesses, and strings are recorded without
+// their text.
+func FromResult(algorithm string, r hasher.Result) (Entry, bool) {
+	alg, ok := hasher.GetAlgorithm(algorithm)
+	if !ok || alg.IsPasswordHash {
+		return Entry{}, false
+	}
+
+	e := Entry{
+		Time:      time.Now().UTC(),
+		Algorithm: algorithm,
+		Kind:      KindString,
+		Digest:    r.Hash,
+		Size:      r.Size,
+	}
+	if r.IsFile {
+		e.Kind = KindFile
+		e.Input = r.Input
+		if abs, err := filepath.Abs(r.Input); err == nil {
+			e.Input = abs
+		}
+	}
+	return e, true
+}
+
+// Stored reports whether the input was kept, so it can be hashed again
+func (e Entry) Stored() bool {
+	return e.Kind == KindFile || (e.Kind == KindString && e.Input != "" && !e.Truncated)
+}
+
+// Label describes the input for display
+func (e Entry) Label() string {
+	switch {
+	case e.Kind == KindFile:
+		return e.Input
+	case e.Kind == KindPassword:
+		return "(password)"
+	case e.Input == "":
+		return fmt.Sprintf("(%d-byte string)", e.Size)
+	}
+	label := strconv.Quote(e.Input)
+	if e.Truncated {
+		label += "…"
+	}
+	return label
+}
+
+// Verify hashes the entry's input again and reports whether it still
+// produces the recorded digest
+func Verify(e Entry, opts hasher.Options) (bool, error) {
+	opts.Algorithm = e.Algorithm
+	var r hasher.Result
+	switch {
+	case e.Kind == KindFile:
+		if alg, ok := hasher.GetAlgorithm(e.Algorithm); ok && alg.IsPasswordHash {
+			// bcrypt salts every hash, so the file is checked against the
+			// recorded one rather than hashed again
+			data, err := os.ReadFile(e.Input)
+			if err != nil {
+				return false, err
+			}
+			return hasher.VerifyPassword(string(data), e.Digest, opts)
+		}
+		r = hasher.HashFile(e.Input, opts)
+	case !e.Stored():
+		return false, errors.New("the input was not stored, so it cannot be verified")
+	default:
+		r = hasher.HashString(e.Input, opts)
+	}
+	if r.Error != nil {
+		return false, r.Error
+	}
+	return r.Hash == e.Digest, nil
+}
+
+// DefaultPath returns the history file under the user data directory
+// ($XDG_DATA_HOME/hashctl on Linux)
+func DefaultPath() string {
+	return filepath.Join(dataDir(), "hashctl", "history.jsonl")
+}
+
+// dataDir follows the XDG base directory spec, with the platform's usual
+// location elsewhere
+func dataDir() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return os.TempDir()
+	}
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LocalAppData"); dir != "" {
+			return dir
+		}
+		return filepath.Join(home, "AppData", "Local")
+	case "darwin", "ios":
+		return filepath.Join(home, "Library", "Application Support")
+	default:
+		return filepath.Join(home, ".local", "share")
+	}
+}
+
+// Store is a history file with one JSON entry per line, oldest first
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Open returns the store at path; the file is created on the first Add
+func Open(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the location of the history file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Add appends entries, compacting the file once it grows large
+func (s *Store) Add(entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	info, err := f.Stat()
+	if err := errors.Join(err, f.Close()); err != nil {
+		return err
+	}
+
+	if info.Size() < compactSize {
+		return nil
+	}
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	if len(all) > Limit {
+		all = all[len(all)-Limit:]
+	}
+	return s.write(all)
+}
+
+// Load returns every entry, newest first. Lines that cannot be parsed are
+// skipped.
+func (s *Store) Load() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, err
+}
+
+// load reads the file oldest first; the caller holds s.mu
+func (s *Store) load() ([]Entry, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err == nil {
+			entries = append(entries, e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", s.path, err)
+	}
+	return entries, nil
+}
+
+// write atomically replaces the file with entries; the caller holds s.mu
+func (s *Store) write(entries []Entry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".history-*.jsonl")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// Clear deletes the history file
+func (s *Store) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Recorder is a hasher.Observer that collects successful results and adds
+// them to the store on Close
+type Recorder struct {
+	store   *Store
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// Recorder returns an observer recording into s
+func (s *Store) Recorder() *Recorder {
+	return &Recorder{store: s}
+}
+
+// Observe records a result; failed results are ignored
+func (r *Recorder) Observe(algorithm string, res hasher.Result) {
+	if res.Error != nil {
+		return
+	}
+	e, ok := FromResult(algorithm, res)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	r.entries = append(r.entries, e)
+	r.mu.Unlock()
+}
+
+// Close writes the collected entries
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.store.Add(r.entries...)
+	r.entries = nil
+	return err
+}
@@ -0,0 +1,71 @@
+// Package logging writes structured logs of hash results with log/slog
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+)
+
+// Levels lists the accepted level names
+var Levels = []string{"debug", "info", "warn", "error"}
+
+// ParseLevel converts a level name into a slog level
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q (use %s)", name, strings.Join(Levels, ", "))
+}
+
+// New returns a JSON logger writing records at or above level to w
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// ResultLogger logs every hash result. It implements hasher.Observer.
+//
+// Successful results are logged at info level, cache hits at debug level
+// and failures at error level. String inputs and password hashes are never
+// logged; only their size is recorded.
+type ResultLogger struct {
+	Logger *slog.Logger
+}
+
+// Observe logs a single result
+func (l ResultLogger) Observe(algorithm string, r hasher.Result) {
+	attrs := []slog.Attr{
+		slog.String("algorithm", algorithm),
+		slog.Int64("size", r.Size),
+		slog.Duration("duration", r.Duration),
+	}
+	if r.IsFile {
+		attrs = append(attrs, slog.String("path", r.Input))
+	}
+
+	if alg, ok := hasher.GetAlgorithm(algorithm); ok && !alg.IsPasswordHash && r.Hash != "" {
+		attrs = append(attrs, slog.String("digest", r.Hash))
+	}
+
+	level, msg := slog.LevelInfo, "hashed"
+	switch {
+	case r.Error != nil:
+		level, msg = slog.LevelError, "hash failed"
+		attrs = append(attrs, slog.String("error", r.Error.Error()))
+	case r.Cached:
+		level, msg = slog.LevelDebug, "cache hit"
+	}
+
+	l.Logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
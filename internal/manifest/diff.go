@@ -0,0 +1,96 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ChangeKind classifies a difference between two manifests
+type ChangeKind string
+
+const (
+	Added    ChangeKind = "added"
+	Removed  ChangeKind = "removed"
+	Modified ChangeKind = "modified"
+	Renamed  ChangeKind = "renamed"
+)
+
+// Change is a single difference between two manifests. For renames Path is
+// the new location and OldPath the previous one.
+type Change struct {
+	Kind    ChangeKind `json:"kind"`
+	Path    string     `json:"path"`
+	OldPath string     `json:"old_path,omitempty"`
+	Old     *Entry     `json:"old,omitempty"`
+	New     *Entry     `json:"new,omitempty"`
+}
+
+// Diff compares two manifests. A removed file whose digest reappears under
+// a new path is reported as a rename instead of a remove/add pair.
+func Diff(a, b *Manifest) ([]Change, error) {
+	if a.Algorithm != b.Algorithm {
+		return nil, fmt.Errorf("manifests use different algorithms: %s and %s", a.Algorithm, b.Algorithm)
+	}
+
+	oldByPath := make(map[string]Entry, len(a.Entries))
+	for _, e := range a.Entries {
+		oldByPath[e.Path] = e
+	}
+	newByPath := make(map[string]Entry, len(b.Entries))
+	for _, e := range b.Entries {
+		newByPath[e.Path] = e
+	}
+
+	var changes []Change
+	var removed, added []Entry
+
+	for _, e := range a.Entries {
+		n, ok := newByPath[e.Path]
+		if !ok {
+			removed = append(removed, e)
+			continue
+		}
+		if n.Digest != e.Digest || n.Size != e.Size || n.Mode != e.Mode {
+			old, cur := e, n
+			changes = append(changes, Change{Kind: Modified, Path: e.Path, Old: &old, New: &cur})
+		}
+	}
+	for _, e := range b.Entries {
+		if _, ok := oldByPath[e.Path]; !ok {
+			added = append(added, e)
+		}
+	}
+
+	// Pair removed and added files with the same content as renames
+	addedByDigest := make(map[string][]int)
+	for i, e := range added {
+		addedByDigest[e.Digest] = append(addedByDigest[e.Digest], i)
+	}
+	renamedTo := make(map[int]bool)
+	for _, e := range removed {
+		candidates := addedByDigest[e.Digest]
+		if len(candidates) == 0 {
+			old := e
+			changes = append(changes, Change{Kind: Removed, Path: e.Path, Old: &old})
+			continue
+		}
+		i := candidates[0]
+		addedByDigest[e.Digest] = candidates[1:]
+		renamedTo[i] = true
+
+		old, cur := e, added[i]
+		changes = append(changes, Change{Kind: Renamed, Path: cur.Path, OldPath: e.Path, Old: &old, New: &cur})
+	}
+	for i, e := range added {
+		if renamedTo[i] {
+			continue
+		}
+		cur := e
+		changes = append(changes, Change{Kind: Added, Path: e.Path, New: &cur})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
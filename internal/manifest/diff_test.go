@@ -0,0 +1,98 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := Entry{Path: "a.txt", Size: 2, Mode: 0o644, Digest: "aa"}
+	b := Entry{Path: "b.txt", Size: 2, Mode: 0o644, Digest: "bb"}
+	c := Entry{Path: "dir/c.txt", Size: 2, Mode: 0o644, Digest: "cc"}
+
+	with := func(e Entry, change func(*Entry)) Entry {
+		change(&e)
+		return e
+	}
+
+	tests := []struct {
+		name     string
+		old, new []Entry
+		want     []string
+	}{
+		{"unchanged", []Entry{a, b}, []Entry{b, a}, nil},
+		{"empty", nil, nil, nil},
+		{"added", []Entry{a}, []Entry{a, b, c}, []string{"added b.txt", "added dir/c.txt"}},
+		{"removed", []Entry{a, b, c}, []Entry{b}, []string{"removed a.txt", "removed dir/c.txt"}},
+		{
+			"modified digest",
+			[]Entry{a, b},
+			[]Entry{with(a, func(e *Entry) { e.Digest = "a2" }), b},
+			[]string{"modified a.txt"},
+		},
+		{
+			"modified size",
+			[]Entry{a},
+			[]Entry{with(a, func(e *Entry) { e.Size = 3 })},
+			[]string{"modified a.txt"},
+		},
+		{
+			"modified mode",
+			[]Entry{a},
+			[]Entry{with(a, func(e *Entry) { e.Mode = 0o755 })},
+			[]string{"modified a.txt"},
+		},
+		{
+			"renamed",
+			[]Entry{a, b},
+			[]Entry{with(a, func(e *Entry) { e.Path = "z.txt" }), b},
+			[]string{"renamed a.txt -> z.txt"},
+		},
+		{
+			// Two removed copies of the same content, one of which reappears
+			"renamed one of two copies",
+			[]Entry{a, with(a, func(e *Entry) { e.Path = "a2.txt" })},
+			[]Entry{with(a, func(e *Entry) { e.Path = "moved.txt" })},
+			[]string{"removed a2.txt", "renamed a.txt -> moved.txt"},
+		},
+		{
+			"mixed",
+			[]Entry{a, b, c},
+			[]Entry{
+				with(b, func(e *Entry) { e.Digest = "b2" }),
+				with(c, func(e *Entry) { e.Path = "dir/d.txt" }),
+				{Path: "new.txt", Size: 1, Mode: 0o644, Digest: "nn"},
+			},
+			[]string{"removed a.txt", "modified b.txt", "renamed dir/c.txt -> dir/d.txt", "added new.txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := Diff(
+				&Manifest{Algorithm: "sha256", Entries: tt.old},
+				&Manifest{Algorithm: "sha256", Entries: tt.new},
+			)
+			if err != nil {
+				t.Fatalf("Diff: %v", err)
+			}
+			var got []string
+			for _, c := range changes {
+				s := string(c.Kind) + " " + c.Path
+				if c.Kind == Renamed {
+					s = string(c.Kind) + " " + c.OldPath + " -> " + c.Path
+				}
+				got = append(got, s)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffAlgorithmMismatch(t *testing.T) {
+	_, err := Diff(&Manifest{Algorithm: "sha256"}, &Manifest{Algorithm: "sha512"})
+	if err == nil {
+		t.Error("Diff of sha256 and sha512 manifests succeeded, want error")
+	}
+}
@@ -0,0 +1,113 @@
+// Package manifest records directory snapshots and compares them
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+)
+
+// Version is the manifest file format version
+const Version = 1
+
+// Entry describes a single file in a snapshot
+type Entry struct {
+	Path   string      `json:"path"` // slash-separated, relative to the root
+	Size   int64       `json:"size"`
+	Mode   fs.FileMode `json:"mode"`
+	Digest string      `json:"digest"`
+}
+
+// Manifest is a snapshot of a directory tree
+type Manifest struct {
+	Version   int       `json:"version"`
+	Algorithm string    `json:"algorithm"`
+	Root      string    `json:"root"`
+	Created   time.Time `json:"created"`
+	Entries   []Entry   `json:"entries"`
+}
+
+// Create hashes every regular file below dir. Files that cannot be read
+// are returned as a joined error alongside the partial manifest.
+func Create(dir string, opts hasher.Options) (*Manifest, error) {
+	alg, ok := hasher.GetAlgorithm(opts.Algorithm)
+	if !ok {
+		return nil, fmt.Errorf("unknown algorithm: %s", opts.Algorithm)
+	}
+	if alg.IsPasswordHash {
+		return nil, fmt.Errorf("%s cannot be used for manifests", alg.Name)
+	}
+
+	m := &Manifest{
+		Version:   Version,
+		Algorithm: opts.Algorithm,
+		Root:      dir,
+		Created:   time.Now().UTC(),
+		Entries:   []Entry{},
+	}
+
+	var errs []error
+	err := hasher.HashDir(dir, opts, func(r hasher.Result) {
+		if r.Error != nil {
+			errs = append(errs, r.Error)
+			return
+		}
+		info, err := os.Lstat(r.Input)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		rel, err := filepath.Rel(dir, r.Input)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		m.Entries = append(m.Entries, Entry{
+			Path:   filepath.ToSlash(rel),
+			Size:   r.Size,
+			Mode:   info.Mode().Perm(),
+			Digest: r.Hash,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(m.Entries, func(i, j int) bool {
+		return m.Entries[i].Path < m.Entries[j].Path
+	})
+	return m, errors.Join(errs...)
+}
+
+// Load reads a manifest file
+func Load(filename string) (*Manifest, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var m Manifest
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	if m.Version != Version {
+		return nil, fmt.Errorf("%s: unsupported manifest version %d", filename, m.Version)
+	}
+	return &m, nil
+}
+
+// Write encodes the manifest as indented JSON
+func (m *Manifest) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
@@ -0,0 +1,161 @@
+// Package metrics records hashing activity in the Prometheus text format
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+)
+
+// DefaultBuckets are the latency histogram bounds in seconds
+var DefaultBuckets = []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5, 10, 30}
+
+// Registry collects per-algorithm counters and latency histograms. It
+// implements hasher.Observer.
+type Registry struct {
+	buckets []float64
+
+	mu   sync.Mutex
+	algs map[string]*series
+}
+
+// series holds the metrics of a single algorithm
+type series struct {
+	bytes     uint64
+	files     uint64
+	errors    uint64
+	cacheHits uint64
+
+	counts []uint64 // per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// New creates an empty registry using DefaultBuckets
+func New() *Registry {
+	return &Registry{
+		buckets: DefaultBuckets,
+		algs:    make(map[string]*series),
+	}
+}
+
+// Observe records a hash result. Cached digests count as cache hits rather
+// than hashed bytes, since nothing was read.
+func (r *Registry) Observe(algorithm string, res hasher.Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.algs[algorithm]
+	if !ok {
+		s = &series{counts: make([]uint64, len(r.buckets))}
+		r.algs[algorithm] = s
+	}
+
+	if res.Error != nil {
+		s.errors++
+		return
+	}
+	if res.Cached {
+		s.cacheHits++
+		return
+	}
+
+	s.bytes += uint64(res.Size)
+	if res.IsFile {
+		s.files++
+	}
+
+	secs := res.Duration.Seconds()
+	s.count++
+	s.sum += secs
+	for i, le := range r.buckets {
+		if secs <= le {
+			s.counts[i]++
+			break
+		}
+	}
+}
+
+// WriteTo writes every metric in the Prometheus text exposition format
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.algs))
+	for name := range r.algs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	counters := []struct {
+		name, help string
+		value      func(*series) uint64
+	}{
+		{"hashctl_hashed_bytes_total", "Bytes read and hashed.", func(s *series) uint64 { return s.bytes }},
+		{"hashctl_hashed_files_total", "Files hashed.", func(s *series) uint64 { return s.files }},
+		{"hashctl_hash_errors_total", "Hash computations that failed.", func(s *series) uint64 { return s.errors }},
+		{"hashctl_cache_hits_total", "Digests served from the digest cache.", func(s *series) uint64 { return s.cacheHits }},
+	}
+	for _, c := range counters {
+		fmt.Fprintf(cw, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+		for _, name := range names {
+			fmt.Fprintf(cw, "%s{algorithm=%q} %d\n", c.name, name, c.value(r.algs[name]))
+		}
+	}
+
+	const hist = "hashctl_hash_duration_seconds"
+	fmt.Fprintf(cw, "# HELP %s Time spent hashing, excluding cache hits.\n# TYPE %s histogram\n", hist, hist)
+	for _, name := range names {
+		s := r.algs[name]
+		var cumulative uint64
+		for i, le := range r.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(cw, "%s_bucket{algorithm=%q,le=%q} %d\n", hist, name, formatFloat(le), cumulative)
+		}
+		fmt.Fprintf(cw, "%s_bucket{algorithm=%q,le=\"+Inf\"} %d\n", hist, name, s.count)
+		fmt.Fprintf(cw, "%s_sum{algorithm=%q} %s\n", hist, name, formatFloat(s.sum))
+		fmt.Fprintf(cw, "%s_count{algorithm=%q} %d\n", hist, name, s.count)
+	}
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+// Handler serves the metrics for Prometheus to scrape
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteTo(w)
+	})
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// countingWriter tracks bytes written and the first error for WriteTo
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,530 @@
+// Package oci verifies content digests of OCI image layouts and docker save archives
+package oci
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+)
+
+// Media types that reference further descriptors
+const (
+	MediaTypeOCIIndex       = "application/vnd.oci.image.index.v1+json"
+	MediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeDockerList     = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// maxDocumentSize bounds how much of a blob is kept in memory for parsing
+const maxDocumentSize = 4 << 20
+
+// ErrNotImage is returned for inputs without index.json or manifest.json
+var ErrNotImage = errors.New("not an OCI image layout or docker save archive")
+
+// Problem classifies a verification finding
+type Problem string
+
+const (
+	ProblemDigestMismatch Problem = "digest mismatch"
+	ProblemSizeMismatch   Problem = "size mismatch"
+	ProblemMissing        Problem = "missing blob"
+	ProblemUnsupported    Problem = "unsupported algorithm"
+	ProblemInvalid        Problem = "invalid document"
+)
+
+// Finding is a single verification failure
+type Finding struct {
+	Path     string  `json:"path"`
+	Problem  Problem `json:"problem"`
+	Expected string  `json:"expected,omitempty"`
+	Actual   string  `json:"actual,omitempty"`
+}
+
+// Report summarises a verification run
+type Report struct {
+	Source     string    `json:"source"`
+	Format     string    `json:"format"` // "oci" or "docker"
+	Blobs      int       `json:"blobs"`
+	References int       `json:"references"`
+	Findings   []Finding `json:"findings"`
+}
+
+// OK reports whether verification found no problems
+func (r Report) OK() bool {
+	return len(r.Findings) == 0
+}
+
+// Descriptor is an OCI content descriptor
+type Descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type index struct {
+	MediaType string       `json:"mediaType"`
+	Manifests []Descriptor `json:"manifests"`
+}
+
+type manifest struct {
+	MediaType string       `json:"mediaType"`
+	Config    Descriptor   `json:"config"`
+	Layers    []Descriptor `json:"layers"`
+}
+
+// dockerManifest is an entry of manifest.json in a docker save archive
+type dockerManifest struct {
+	Config string   `json:"Config"`
+	Layers []string `json:"Layers"`
+}
+
+type dockerConfig struct {
+	RootFS struct {
+		DiffIDs []string `json:"diff_ids"`
+	} `json:"rootfs"`
+}
+
+// entry is a file of the layout with its recomputed digest
+type entry struct {
+	size   int64
+	digest string // "<algorithm>:<hex>"
+	data   []byte // kept for likely documents, tar sources only
+	err    error
+}
+
+// source is a directory or tarball indexed by slash-separated path
+type source struct {
+	dir     string
+	tarball string
+	entries map[string]*entry
+}
+
+// Verify recomputes every blob digest in an OCI image layout directory or a
+// docker save tarball (optionally gzip-compressed) and checks all
+// descriptor references against them.
+func Verify(target string, opts hasher.Options) (Report, error) {
+	info, err := os.Stat(target)
+	if err != nil {
+		return Report{}, err
+	}
+
+	var src *source
+	if info.IsDir() {
+		src, err = loadDir(target, opts)
+	} else {
+		src, err = loadTar(target, opts)
+	}
+	if err != nil {
+		return Report{}, err
+	}
+
+	v := &verifier{src: src, seen: make(map[string]bool)}
+	v.report.Source = target
+	v.report.Findings = []Finding{}
+	v.checkBlobs()
+
+	switch {
+	case src.entries["index.json"] != nil:
+		v.report.Format = "oci"
+		v.walkDocument("index.json", MediaTypeOCIIndex)
+	case src.entries["manifest.json"] != nil:
+		v.report.Format = "docker"
+		v.walkDocker()
+	default:
+		return Report{}, fmt.Errorf("%s: %w", target, ErrNotImage)
+	}
+
+	return v.report, nil
+}
+
+// blobAlgorithm returns the digest algorithm implied by a blob path
+func blobAlgorithm(p string) (alg, encoded string, ok bool) {
+	parts := strings.Split(p, "/")
+	if len(parts) != 3 || parts[0] != "blobs" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// digestAlgorithm picks the algorithm used to recompute a file's digest
+func digestAlgorithm(p string) string {
+	if alg, _, ok := blobAlgorithm(p); ok {
+		return alg
+	}
+	return "sha256"
+}
+
+func supported(alg string) bool {
+	a, ok := hasher.GetAlgorithm(alg)
+	return ok && !a.IsPasswordHash && (alg == "sha256" || alg == "sha512")
+}
+
+func loadDir(dir string, opts hasher.Options) (*source, error) {
+	src := &source{dir: dir, entries: make(map[string]*entry)}
+	byAlg := make(map[string][]string)
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		src.entries[rel] = &entry{size: info.Size()}
+		alg := digestAlgorithm(rel)
+		byAlg[alg] = append(byAlg[alg], p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for alg, files := range byAlg {
+		if !supported(alg) {
+			continue
+		}
+		o := opts
+		o.Algorithm = alg
+		hasher.HashFiles(files, o, func(r hasher.Result) {
+			rel, _ := filepath.Rel(dir, r.Input)
+			e := src.entries[filepath.ToSlash(rel)]
+			e.digest = alg + ":" + r.Hash
+			e.err = r.Error
+		})
+	}
+	return src, nil
+}
+
+// openTar opens a tarball, gzip-compressed or not
+func openTar(name string) (*tar.Reader, func(), error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	br := bufio.NewReader(f)
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return tar.NewReader(gz), func() { gz.Close(); f.Close() }, nil
+	}
+	return tar.NewReader(br), func() { f.Close() }, nil
+}
+
+func loadTar(name string, opts hasher.Options) (*source, error) {
+	tr, closeTar, err := openTar(name)
+	if err != nil {
+		return nil, err
+	}
+	defer closeTar()
+
+	src := &source{tarball: name, entries: make(map[string]*entry)}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		p := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		e := &entry{size: hdr.Size}
+		src.entries[p] = e
+
+		alg := digestAlgorithm(p)
+		if !supported(alg) {
+			continue
+		}
+
+		br := bufio.NewReader(tr)
+		var body io.Reader = br
+		var buf bytes.Buffer
+		keep := hdr.Size <= maxDocumentSize && likelyDocument(p, br)
+		if keep {
+			body = io.TeeReader(br, &buf)
+		}
+
+		o := opts
+		o.Algorithm = alg
+		res := hasher.HashReader(p, body, o)
+		e.digest = alg + ":" + res.Hash
+		e.err = res.Error
+		if keep {
+			e.data = buf.Bytes()
+		}
+	}
+	return src, nil
+}
+
+// likelyDocument reports whether a tar entry may be parsed as JSON later:
+// top-level .json files and blobs that start like a JSON object. Layers
+// are not kept in memory; document reads anything else again on demand.
+func likelyDocument(p string, br *bufio.Reader) bool {
+	if _, _, isBlob := blobAlgorithm(p); !isBlob {
+		return strings.HasSuffix(p, ".json")
+	}
+	for i := 1; ; i++ {
+		b, err := br.Peek(i)
+		if err != nil || i > br.Size() {
+			return false
+		}
+		switch b[i-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// document returns the contents of a small file for parsing
+func (s *source) document(p string) ([]byte, error) {
+	e := s.entries[p]
+	if e == nil {
+		return nil, fs.ErrNotExist
+	}
+	if e.size > maxDocumentSize {
+		return nil, fmt.Errorf("%s: document too large (%d bytes)", p, e.size)
+	}
+	if s.dir != "" {
+		return os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(p)))
+	}
+	if e.data != nil || e.size == 0 {
+		return e.data, nil
+	}
+	return s.readTarEntry(p)
+}
+
+// readTarEntry reads one file of the tarball again, for documents that
+// were not kept while hashing
+func (s *source) readTarEntry(p string) ([]byte, error) {
+	tr, closeTar, err := openTar(s.tarball)
+	if err != nil {
+		return nil, err
+	}
+	defer closeTar()
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s: %w", p, fs.ErrNotExist)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.tarball, err)
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Clean(strings.TrimPrefix(hdr.Name, "./")) == p {
+			return io.ReadAll(io.LimitReader(tr, maxDocumentSize))
+		}
+	}
+}
+
+type verifier struct {
+	src    *source
+	seen   map[string]bool
+	report Report
+}
+
+func (v *verifier) add(f Finding) {
+	v.report.Findings = append(v.report.Findings, f)
+}
+
+// checkBlobs compares every blob under blobs/ with the digest in its path
+func (v *verifier) checkBlobs() {
+	paths := make([]string, 0, len(v.src.entries))
+	for p := range v.src.entries {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		e := v.src.entries[p]
+		alg, encoded, ok := blobAlgorithm(p)
+		if !ok {
+			continue
+		}
+		v.report.Blobs++
+
+		expected := alg + ":" + encoded
+		switch {
+		case !supported(alg):
+			v.add(Finding{Path: p, Problem: ProblemUnsupported, Expected: expected})
+		case e.err != nil:
+			v.add(Finding{Path: p, Problem: ProblemInvalid, Actual: e.err.Error()})
+		case e.digest != expected:
+			v.add(Finding{Path: p, Problem: ProblemDigestMismatch, Expected: expected, Actual: e.digest})
+		}
+	}
+}
+
+// checkDescriptor verifies that a referenced blob exists with the right size
+// and returns its path when it can be inspected further
+func (v *verifier) checkDescriptor(d Descriptor) (string, bool) {
+	v.report.References++
+
+	alg, encoded, found := strings.Cut(d.Digest, ":")
+	if !found || encoded == "" || strings.Contains(encoded, "/") {
+		v.add(Finding{Path: d.Digest, Problem: ProblemInvalid, Actual: "malformed digest"})
+		return "", false
+	}
+	p := path.Join("blobs", alg, encoded)
+
+	e := v.src.entries[p]
+	if e == nil {
+		v.add(Finding{Path: p, Problem: ProblemMissing, Expected: d.Digest})
+		return "", false
+	}
+	if e.size != d.Size {
+		v.add(Finding{
+			Path:     p,
+			Problem:  ProblemSizeMismatch,
+			Expected: fmt.Sprintf("%d", d.Size),
+			Actual:   fmt.Sprintf("%d", e.size),
+		})
+		return "", false
+	}
+	return p, true
+}
+
+func (v *verifier) walkDescriptor(d Descriptor) {
+	p, ok := v.checkDescriptor(d)
+	if !ok || v.seen[p] {
+		return
+	}
+	v.seen[p] = true
+	v.walkDocument(p, d.MediaType)
+}
+
+// walkDocument parses an index or manifest and follows its descriptors
+func (v *verifier) walkDocument(p, mediaType string) {
+	switch mediaType {
+	case MediaTypeOCIIndex, MediaTypeDockerList, MediaTypeOCIManifest, MediaTypeDockerManifest:
+	default:
+		return
+	}
+
+	data, err := v.src.document(p)
+	if err != nil {
+		v.add(Finding{Path: p, Problem: ProblemInvalid, Actual: err.Error()})
+		return
+	}
+
+	if mediaType == MediaTypeOCIIndex || mediaType == MediaTypeDockerList {
+		var idx index
+		if err := json.Unmarshal(data, &idx); err != nil {
+			v.add(Finding{Path: p, Problem: ProblemInvalid, Actual: err.Error()})
+			return
+		}
+		for _, d := range idx.Manifests {
+			v.walkDescriptor(d)
+		}
+		return
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		v.add(Finding{Path: p, Problem: ProblemInvalid, Actual: err.Error()})
+		return
+	}
+	v.walkDescriptor(m.Config)
+	for _, d := range m.Layers {
+		v.walkDescriptor(d)
+	}
+}
+
+// walkDocker checks a legacy docker save manifest.json: config files are
+// named after their digest and layer tarballs must match the config's
+// uncompressed diff_ids.
+func (v *verifier) walkDocker() {
+	data, err := v.src.document("manifest.json")
+	if err != nil {
+		v.add(Finding{Path: "manifest.json", Problem: ProblemInvalid, Actual: err.Error()})
+		return
+	}
+
+	var images []dockerManifest
+	if err := json.Unmarshal(data, &images); err != nil {
+		v.add(Finding{Path: "manifest.json", Problem: ProblemInvalid, Actual: err.Error()})
+		return
+	}
+
+	for _, img := range images {
+		v.report.References++
+		cfgPath := path.Clean(img.Config)
+		cfg := v.src.entries[cfgPath]
+		if cfg == nil {
+			v.add(Finding{Path: cfgPath, Problem: ProblemMissing})
+			continue
+		}
+		if name := strings.TrimSuffix(path.Base(cfgPath), ".json"); len(name) == 64 {
+			if expected := "sha256:" + name; cfg.digest != expected {
+				v.add(Finding{Path: cfgPath, Problem: ProblemDigestMismatch, Expected: expected, Actual: cfg.digest})
+			}
+		}
+
+		var config dockerConfig
+		data, err := v.src.document(cfgPath)
+		if err == nil {
+			err = json.Unmarshal(data, &config)
+		}
+		if err != nil {
+			v.add(Finding{Path: cfgPath, Problem: ProblemInvalid, Actual: err.Error()})
+			continue
+		}
+		if len(config.RootFS.DiffIDs) != len(img.Layers) {
+			v.add(Finding{
+				Path:     cfgPath,
+				Problem:  ProblemInvalid,
+				Expected: fmt.Sprintf("%d layers", len(img.Layers)),
+				Actual:   fmt.Sprintf("%d diff_ids", len(config.RootFS.DiffIDs)),
+			})
+			continue
+		}
+
+		for i, l := range img.Layers {
+			v.report.References++
+			layerPath := path.Clean(l)
+			layer := v.src.entries[layerPath]
+			expected := config.RootFS.DiffIDs[i]
+			switch {
+			case layer == nil:
+				v.add(Finding{Path: layerPath, Problem: ProblemMissing, Expected: expected})
+			case layer.err != nil:
+				v.add(Finding{Path: layerPath, Problem: ProblemInvalid, Actual: layer.err.Error()})
+			case layer.digest != expected:
+				v.add(Finding{Path: layerPath, Problem: ProblemDigestMismatch, Expected: expected, Actual: layer.digest})
+			}
+		}
+	}
+}
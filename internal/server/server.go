@@ -0,0 +1,437 @@
+// Package server exposes hashing over a local HTTP API
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Config controls the HTTP service
+type Config struct {
+	// Options supplies defaults for every request; Options.Parallelism
+	// bounds how many requests hash at the same time
+	Options hasher.Options
+	// Root is the directory server-side paths are resolved in; empty
+	// disables the path endpoint
+	Root string
+	// MaxBodySize limits uploaded bodies in bytes
+	MaxBodySize int64
+	// ShutdownTimeout bounds how long in-flight requests may finish
+	ShutdownTimeout time.Duration
+	// Metrics, if set, is served at /metrics
+	Metrics http.Handler
+	// Policy, if set, hides and refuses forbidden algorithms
+	Policy *hasher.Policy
+	// MaxBcryptCost refuses to verify bcrypt hashes of a higher cost, since
+	// the client picks the work factor; zero means Options.BcryptCost
+	MaxBcryptCost int
+}
+
+// DefaultConfig returns sensible defaults
+func DefaultConfig() Config {
+	return Config{
+		Options:         hasher.DefaultOptions(),
+		MaxBodySize:     1 << 30,
+		ShutdownTimeout: 10 * time.Second,
+	}
+}
+
+// maxJSONSize limits JSON request documents
+const maxJSONSize = 1 << 20
+
+// Server handles hashing requests
+type Server struct {
+	cfg  Config
+	root string
+	sem  chan struct{}
+	mux  *http.ServeMux
+}
+
+// New creates a server. The root directory, if set, must exist.
+func New(cfg Config) (*Server, error) {
+	if cfg.Options.Parallelism < 1 {
+		cfg.Options.Parallelism = 1
+	}
+
+	s := &Server{
+		cfg: cfg,
+		sem: make(chan struct{}, cfg.Options.Parallelism),
+		mux: http.NewServeMux(),
+	}
+
+	if cfg.Root != "" {
+		root, err := filepath.Abs(cfg.Root)
+		if err == nil {
+			root, err = filepath.EvalSymlinks(root)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid root: %w", err)
+		}
+		s.root = root
+	}
+
+	s.mux.HandleFunc("/v1/algorithms", s.handleAlgorithms)
+	s.mux.HandleFunc("/v1/hash", s.limit(s.handleHashBody))
+	s.mux.HandleFunc("/v1/hash/path", s.limit(s.handleHashPath))
+	s.mux.HandleFunc("/v1/verify", s.limit(s.handleVerify))
+	if cfg.Metrics != nil {
+		s.mux.Handle("/metrics", cfg.Metrics)
+	}
+	s.mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Serve handles requests on ln until ctx is cancelled, then waits for
+// in-flight requests up to the shutdown timeout
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Listen opens a TCP address, or a Unix socket for "unix:/path" addresses.
+// A stale socket file left by a previous run is removed first.
+func Listen(addr string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			os.Remove(path)
+		}
+		return net.Listen("unix", path)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// limit bounds concurrent hashing to Options.Parallelism
+func (s *Server) limit(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case s.sem <- struct{}{}:
+			defer func() { <-s.sem }()
+			next(w, r)
+		case <-r.Context().Done():
+			writeError(w, http.StatusServiceUnavailable, errors.New("request cancelled while waiting for a worker"))
+		}
+	}
+}
+
+type algorithmInfo struct {
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Category     string `json:"category"`
+	PasswordHash bool   `json:"password_hash"`
+	Multicodec   uint64 `json:"multicodec,omitempty"`
+	Status       string `json:"status,omitempty"`
+}
+
+func (s *Server) handleAlgorithms(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("use GET"))
+		return
+	}
+
+	var algs []algorithmInfo
+	for _, key := range hasher.ListNames() {
+		alg := hasher.Registry[key]
+		info := algorithmInfo{
+			Key:          key,
+			Name:         alg.Name,
+			Description:  alg.Description,
+			Category:     alg.Category.String(),
+			PasswordHash: alg.IsPasswordHash,
+			Multicodec:   alg.Multicodec,
+		}
+		if s.cfg.Policy != nil {
+			if !s.cfg.Policy.Allowed(key) {
+				continue
+			}
+			info.Status = s.cfg.Policy.Status(key).String()
+		}
+		algs = append(algs, info)
+	}
+	writeJSON(w, http.StatusOK, algs)
+}
+
+type hashResponse struct {
+	Algorithm  string  `json:"algorithm"`
+	Digest     string  `json:"digest"`
+	Size       int64   `json:"size"`
+	Path       string  `json:"path,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+func newHashResponse(algorithm string, r hasher.Result) hashResponse {
+	return hashResponse{
+		Algorithm:  algorithm,
+		Digest:     r.Hash,
+		Size:       r.Size,
+		DurationMS: float64(r.Duration.Microseconds()) / 1000,
+	}
+}
+
+// options resolves the request's algorithm against the server defaults
+func (s *Server) options(algorithm string) (hasher.Options, error) {
+	opts := s.cfg.Options
+	if algorithm != "" {
+		opts.Algorithm = algorithm
+	}
+	if _, ok := hasher.GetAlgorithm(opts.Algorithm); !ok {
+		return opts, fmt.Errorf("unknown algorithm: %s", opts.Algorithm)
+	}
+	if s.cfg.Policy != nil {
+		if err := s.cfg.Policy.Check(opts.Algorithm); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// handleHashBody streams the request body through the hash:
+// POST /v1/hash?algorithm=sha256
+func (s *Server) handleHashBody(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("use POST"))
+		return
+	}
+
+	opts, err := s.options(r.URL.Query().Get("algorithm"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	alg, _ := hasher.GetAlgorithm(opts.Algorithm)
+
+	var res hasher.Result
+	if alg.IsPasswordHash {
+		// Password hashes need the whole input; bodies are small passwords
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONSize))
+		if err != nil {
+			writeBodyError(w, err)
+			return
+		}
+		res = hasher.HashString(string(data), opts)
+	} else {
+		res = hasher.HashReader("body", http.MaxBytesReader(w, r.Body, s.cfg.MaxBodySize), opts)
+	}
+
+	if res.Error != nil {
+		writeBodyError(w, res.Error)
+		return
+	}
+	writeJSON(w, http.StatusOK, newHashResponse(opts.Algorithm, res))
+}
+
+type pathRequest struct {
+	Path      string `json:"path"`
+	Algorithm string `json:"algorithm"`
+}
+
+// handleHashPath hashes a file below the configured root:
+// GET /v1/hash/path?path=rel/file&algorithm=sha256 or POST {"path": …}
+func (s *Server) handleHashPath(w http.ResponseWriter, r *http.Request) {
+	if s.root == "" {
+		writeError(w, http.StatusForbidden, errors.New("server-side paths are disabled; start with --root"))
+		return
+	}
+
+	var req pathRequest
+	switch r.Method {
+	case http.MethodGet:
+		req.Path = r.URL.Query().Get("path")
+		req.Algorithm = r.URL.Query().Get("algorithm")
+	case http.MethodPost:
+		if err := decodeJSON(w, r, &req); err != nil {
+			writeBodyError(w, err)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("use GET or POST"))
+		return
+	}
+
+	opts, err := s.options(req.Algorithm)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	full, err := s.resolve(req.Path)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	res := hasher.HashFile(full, opts)
+	if res.Error != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(res.Error, os.ErrNotExist) {
+			status = http.StatusNotFound
+		}
+		// Do not leak absolute server paths in error messages
+		err := res.Error
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) {
+			err = fmt.Errorf("%s: %w", pathErr.Op, pathErr.Err)
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	resp := newHashResponse(opts.Algorithm, res)
+	resp.Path = req.Path
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// resolve maps a request path into the root, following symlinks so that
+// links pointing outside the root are refused
+func (s *Server) resolve(p string) (string, error) {
+	if p == "" {
+		return "", errors.New("missing path")
+	}
+	full := filepath.Join(s.root, filepath.FromSlash(p))
+	resolved, err := filepath.EvalSymlinks(full)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", errors.New("path not found")
+		}
+		return "", errors.New("path not accessible")
+	}
+	if resolved != s.root && !strings.HasPrefix(resolved, s.root+string(filepath.Separator)) {
+		return "", errors.New("path escapes the allowed root")
+	}
+	info, err := os.Stat(resolved)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", errors.New("path is not a regular file")
+	}
+	return resolved, nil
+}
+
+type verifyRequest struct {
+	Algorithm string `json:"algorithm"`
+	Password  string `json:"password"`
+	Hash      string `json:"hash"`
+}
+
+// handleVerify checks a password against a bcrypt or argon2id hash:
+// POST /v1/verify {"algorithm": "bcrypt", "password": …, "hash": …}
+func (s *Server) handleVerify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("use POST"))
+		return
+	}
+
+	var req verifyRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeBodyError(w, err)
+		return
+	}
+	if strings.TrimSpace(req.Hash) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("hash is required"))
+		return
+	}
+	opts, err := s.options(req.Algorithm)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if opts.Algorithm == "bcrypt" {
+		if err := s.checkBcryptCost(req.Hash); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	match, err := hasher.VerifyPassword(req.Password, req.Hash, opts)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"match": match})
+}
+
+// checkBcryptCost refuses hashes that would take longer to verify than
+// the server allows
+func (s *Server) checkBcryptCost(hashed string) error {
+	cost, err := bcrypt.Cost([]byte(hashed))
+	if err != nil {
+		return fmt.Errorf("invalid bcrypt hash: %w", err)
+	}
+	limit := s.cfg.MaxBcryptCost
+	if limit == 0 {
+		limit = s.cfg.Options.BcryptCost
+	}
+	if cost > limit {
+		return fmt.Errorf("bcrypt cost %d exceeds the maximum of %d", cost, limit)
+	}
+	return nil
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeBodyError maps body read failures to 413 when the size limit was hit
+func writeBodyError(w http.ResponseWriter, err error) {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("body exceeds %d bytes", maxErr.Limit))
+		return
+	}
+	writeError(w, http.StatusBadRequest, err)
+}
@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/bench"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// benchKey identifies one measurement
+type benchKey struct {
+	algorithm string
+	size      int64
+	workers   int
+}
+
+// BenchModel shows throughput bars per algorithm while a benchmark runs
+type BenchModel struct {
+	cfg     bench.Config
+	results map[benchKey]bench.Measurement
+	updates <-chan bench.Measurement
+	done    bool
+	err     *error
+
+	sizeIndex int
+	parallel  bool
+
+	width  int
+	height int
+}
+
+type benchResultMsg bench.Measurement
+
+type benchDoneMsg struct{}
+
+// NewBenchModel creates the benchmark screen fed by updates, which is
+// closed when the benchmark finishes; err is read after that
+func NewBenchModel(cfg bench.Config, updates <-chan bench.Measurement, err *error) BenchModel {
+	return BenchModel{
+		cfg:     cfg,
+		results: make(map[benchKey]bench.Measurement),
+		updates: updates,
+		err:     err,
+		width:   80,
+		height:  24,
+	}
+}
+
+// Init starts listening for measurements
+func (m BenchModel) Init() tea.Cmd {
+	return m.waitForResult()
+}
+
+func (m BenchModel) waitForResult() tea.Cmd {
+	return func() tea.Msg {
+		r, ok := <-m.updates
+		if !ok {
+			return benchDoneMsg{}
+		}
+		return benchResultMsg(r)
+	}
+}
+
+// Update handles measurements and navigation
+func (m BenchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "left", "h":
+			if m.sizeIndex > 0 {
+				m.sizeIndex--
+			}
+		case "right", "l":
+			if m.sizeIndex < len(m.cfg.Sizes)-1 {
+				m.sizeIndex++
+			}
+		case "tab", "p":
+			if m.cfg.Parallelism > 1 {
+				m.parallel = !m.parallel
+			}
+		}
+
+	case benchResultMsg:
+		r := bench.Measurement(msg)
+		m.results[benchKey{r.Algorithm, r.Size, r.Workers}] = r
+		return m, m.waitForResult()
+
+	case benchDoneMsg:
+		m.done = true
+	}
+	return m, nil
+}
+
+// View renders one bar per algorithm for the selected size and mode
+func (m BenchModel) View() string {
+	var s strings.Builder
+
+	s.WriteString(LogoStyle.Render("hashctl"))
+	s.WriteString(LogoAccent.Render(" ⟡ "))
+	s.WriteString(LabelStyle.Render("BENCH"))
+	s.WriteString("\n")
+
+	switch {
+	case m.done && m.err != nil && *m.err != nil:
+		s.WriteString(ErrorStyle.Render("✗ " + (*m.err).Error()))
+	case m.done:
+		s.WriteString(SuccessStyle.Render(fmt.Sprintf("✓ %d measurements", len(m.results))))
+	default:
+		s.WriteString(SubtitleStyle.Render(fmt.Sprintf("measuring %d/%d", len(m.results), m.cfg.Runs())))
+	}
+	s.WriteString("\n\n")
+
+	if len(m.cfg.Sizes) == 0 {
+		return AppStyle.Render(s.String())
+	}
+	size := m.cfg.Sizes[m.sizeIndex]
+	workers := 1
+	mode := "single core"
+	if m.parallel {
+		workers = m.cfg.Parallelism
+		mode = fmt.Sprintf("%d workers", workers)
+	}
+	s.WriteString(MutedStyle.Render("size "))
+	s.WriteString(SelectedStyle.Render("◂ " + bench.FormatSize(size) + " ▸"))
+	s.WriteString(MutedStyle.Render("   mode "))
+	s.WriteString(SelectedStyle.Render(mode))
+	s.WriteString("\n\n")
+
+	type row struct {
+		algorithm string
+		mbps      float64
+		ok        bool
+	}
+	rows := make([]row, 0, len(m.cfg.Algorithms))
+	best := 0.0
+	for _, alg := range m.cfg.Algorithms {
+		r, ok := m.results[benchKey{alg, size, workers}]
+		rows = append(rows, row{alg, r.MBps, ok})
+		best = max(best, r.MBps)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].mbps > rows[j].mbps
+	})
+
+	barWidth := max(m.width-40, 10)
+	for _, r := range rows {
+		s.WriteString(LabelStyle.Render(fmt.Sprintf("%-14s", r.algorithm)))
+		if !r.ok {
+			s.WriteString(DimStyle.Render("…"))
+			s.WriteString("\n")
+			continue
+		}
+		n := 0
+		if best > 0 {
+			n = max(int(r.mbps/best*float64(barWidth)), 1)
+		}
+		s.WriteString(HashStyle.Render(strings.Repeat("█", n)))
+		s.WriteString(DimStyle.Render(strings.Repeat("░", barWidth-n)))
+		s.WriteString(ValueStyle.Render(fmt.Sprintf(" %9.1f MB/s", r.mbps)))
+		s.WriteString("\n")
+	}
+
+	help := "←/→ size • q quit"
+	if m.cfg.Parallelism > 1 {
+		help = "←/→ size • tab single/parallel • q quit"
+	}
+	s.WriteString(HelpStyle.Render(help))
+
+	return AppStyle.Render(s.String())
+}
+
+// RunBench runs the benchmark behind the live bars screen
+func RunBench(cfg bench.Config) error {
+	// Buffered for every measurement so the benchmark never blocks on the UI
+	updates := make(chan bench.Measurement, cfg.Runs())
+	var runErr error
+	go func() {
+		_, runErr = bench.Run(cfg, func(r bench.Measurement) {
+			updates <- r
+		})
+		close(updates)
+	}()
+
+	p := tea.NewProgram(NewBenchModel(cfg, updates, &runErr), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
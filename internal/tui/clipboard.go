@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// clipboardMsg reports the outcome of a copy
+type clipboardMsg struct {
+	method string
+	err    error
+}
+
+// copyToClipboard copies s with the system clipboard, falling back to an
+// OSC 52 escape sequence that most terminals apply to the local clipboard.
+// Over SSH OSC 52 goes first, since the system clipboard there is the
+// remote one.
+func copyToClipboard(s string) tea.Cmd {
+	return func() tea.Msg {
+		overSSH := os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+		if !overSSH && !clipboard.Unsupported {
+			if err := clipboard.WriteAll(s); err == nil {
+				return clipboardMsg{method: "system clipboard"}
+			}
+		}
+
+		// The program's renderer writes to stdout too; the sequence is a
+		// single write that the terminal consumes without drawing anything,
+		// so it can go out between frames without leaving the alt screen.
+		// tea.Printf cannot be used, since it prints nothing in the alt
+		// screen.
+		out := termenv.NewOutput(os.Stdout)
+		if os.Getenv("TMUX") != "" {
+			_, err := osc52.New(s).Tmux().WriteTo(out)
+			return clipboardMsg{method: "OSC 52", err: err}
+		}
+		out.Copy(s) // wraps the sequence for screen itself
+		return clipboardMsg{method: "OSC 52"}
+	}
+}
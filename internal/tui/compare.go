@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+)
+
+// HighlightDiff renders two digests character by character, marking the
+// positions where they differ. Case is ignored so hex from any tool lines up.
+func HighlightDiff(want, got string) (string, string) {
+	var w, g strings.Builder
+	n := max(len(want), len(got))
+	for i := 0; i < n; i++ {
+		var a, b string
+		if i < len(want) {
+			a = want[i : i+1]
+		}
+		if i < len(got) {
+			b = got[i : i+1]
+		}
+		if strings.EqualFold(a, b) {
+			w.WriteString(DimStyle.Render(a))
+			g.WriteString(DimStyle.Render(b))
+			continue
+		}
+		w.WriteString(WarningStyle.Render(a))
+		g.WriteString(ErrorStyle.Render(b))
+	}
+	return w.String(), g.String()
+}
+
+// Verdict renders a match or mismatch line
+func Verdict(match bool, label string) string {
+	if label != "" {
+		label = " " + label
+	}
+	if match {
+		return SuccessStyle.Render("✓ match" + label)
+	}
+	return ErrorStyle.Render("✗ MISMATCH" + label)
+}
+
+// verdict is the outcome of comparing a result with the expected digest
+type verdict struct {
+	match bool
+	err   error
+}
+
+// judge compares a result with the expected digest, if there is one. For
+// a password hash this is a full bcrypt or argon2id run, so it happens once
+// as the result arrives and never while rendering or sorting.
+func judge(e *hasher.Expected, r hasher.Result, opts hasher.Options) verdict {
+	if e == nil || r.Error != nil {
+		return verdict{}
+	}
+	match, err := e.Match(r, opts)
+	return verdict{match: match, err: err}
+}
+
+// verdictOf returns the stored verdict for m.results[i]
+func (m Model) verdictOf(i int) verdict {
+	if i < len(m.verdicts) {
+		return m.verdicts[i]
+	}
+	return verdict{}
+}
+
+// viewComparison renders the verdict for m.results[i] against the
+// expected digest
+func (m Model) viewComparison(i int) string {
+	var s strings.Builder
+	e := m.expected
+	r := m.results[i]
+
+	v := m.verdictOf(i)
+	if v.err != nil {
+		s.WriteString(ErrorStyle.Render("✗ " + v.err.Error()))
+		return s.String()
+	}
+
+	s.WriteString(Verdict(v.match, ""))
+	if e.Explicit && e.Algorithm != "" && e.Algorithm != m.opts.Algorithm {
+		s.WriteString("\n")
+		s.WriteString(WarningStyle.Render("⚠ the expected hash looks like " + e.Algorithm))
+	} else if alg, ok := hasher.GetAlgorithm(m.opts.Algorithm); ok && e.Digest != nil && !alg.IsPasswordHash && alg.NewHash().Size() != len(e.Digest) {
+		s.WriteString("\n")
+		s.WriteString(WarningStyle.Render(fmt.Sprintf("⚠ the expected hash has %d bytes; %s produces %d",
+			len(e.Digest), m.opts.Algorithm, alg.NewHash().Size())))
+	}
+	if v.match || e.Digest == nil {
+		return s.String()
+	}
+
+	want, got := HighlightDiff(e.Hex(), r.Hash)
+	s.WriteString("\n\n")
+	s.WriteString(MutedStyle.Render("expected "))
+	s.WriteString(want)
+	s.WriteString("\n")
+	s.WriteString(MutedStyle.Render("actual   "))
+	s.WriteString(got)
+	return s.String()
+}
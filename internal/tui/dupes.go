@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/dupes"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DupesModel browses duplicate file groups
+type DupesModel struct {
+	groups   []dupes.Group
+	stats    dupes.Stats
+	index    int
+	expanded map[int]bool
+	offset   int
+
+	width  int
+	height int
+}
+
+// NewDupesModel creates a browser for the given duplicate groups
+func NewDupesModel(groups []dupes.Group, stats dupes.Stats) DupesModel {
+	return DupesModel{
+		groups:   groups,
+		stats:    stats,
+		expanded: make(map[int]bool),
+		width:    80,
+		height:   24,
+	}
+}
+
+// Init initializes the model
+func (m DupesModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles navigation keys
+func (m DupesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.index > 0 {
+				m.index--
+			}
+		case "down", "j":
+			if m.index < len(m.groups)-1 {
+				m.index++
+			}
+		case "enter", " ":
+			m.expanded[m.index] = !m.expanded[m.index]
+		case "e":
+			all := len(m.expanded) < len(m.groups)
+			for i := range m.groups {
+				m.expanded[i] = all
+			}
+			if !all {
+				m.expanded = make(map[int]bool)
+			}
+		case "home", "g":
+			m.index = 0
+		case "end", "G":
+			m.index = len(m.groups) - 1
+		}
+	}
+
+	// Keep the cursor on screen
+	visible := m.visibleGroups()
+	if m.index < m.offset {
+		m.offset = m.index
+	} else if m.index >= m.offset+visible {
+		m.offset = m.index - visible + 1
+	}
+	return m, nil
+}
+
+// visibleGroups estimates how many collapsed groups fit on screen
+func (m DupesModel) visibleGroups() int {
+	// header, summary, help and padding take about 10 lines
+	n := m.height - 10
+	if n < 3 {
+		n = 3
+	}
+	return n
+}
+
+// View renders the group list
+func (m DupesModel) View() string {
+	var s strings.Builder
+
+	s.WriteString(LogoStyle.Render("hashctl"))
+	s.WriteString(LogoAccent.Render(" ⟡ "))
+	s.WriteString(LabelStyle.Render("DUPLICATES"))
+	s.WriteString("\n\n")
+
+	if len(m.groups) == 0 {
+		s.WriteString(SuccessStyle.Render("✓ no duplicates found"))
+		s.WriteString("\n")
+		s.WriteString(HelpStyle.Render("q quit"))
+		return AppStyle.Render(s.String())
+	}
+
+	s.WriteString(MutedStyle.Render(fmt.Sprintf("%d sets • %s reclaimable • %d files scanned",
+		len(m.groups), FormatBytes(m.stats.Wasted), m.stats.Scanned)))
+	s.WriteString("\n\n")
+
+	end := m.offset + m.visibleGroups()
+	if end > len(m.groups) {
+		end = len(m.groups)
+	}
+	for i := m.offset; i < end; i++ {
+		g := m.groups[i]
+		line := fmt.Sprintf("%d × %s  %s", len(g.Files), FormatBytes(g.Size), g.Files[0])
+
+		if i == m.index {
+			s.WriteString(Cursor())
+			s.WriteString(SelectedStyle.Render(truncate(line, max(m.width-8, 20))))
+		} else {
+			s.WriteString(NoCursor())
+			s.WriteString(UnselectedStyle.Render(truncate(line, max(m.width-8, 20))))
+		}
+		s.WriteString("\n")
+
+		if m.expanded[i] {
+			s.WriteString(DimStyle.Render("    " + g.Digest))
+			s.WriteString("\n")
+			for j, f := range g.Files {
+				marker := "    dup  "
+				if j == 0 {
+					marker = "    keep "
+				}
+				s.WriteString(MutedStyle.Render(marker))
+				s.WriteString(ValueStyle.Render(f))
+				s.WriteString("\n")
+			}
+			s.WriteString(WarningStyle.Render(fmt.Sprintf("    %s wasted", FormatBytes(g.Wasted()))))
+			s.WriteString("\n")
+		}
+	}
+
+	s.WriteString(HelpStyle.Render("↑/↓ select • enter expand • e expand all • q quit"))
+
+	return AppStyle.Render(s.String())
+}
+
+// RunDupes starts the duplicate browser
+func RunDupes(groups []dupes.Group, stats dupes.Stats) error {
+	p := tea.NewProgram(NewDupesModel(groups, stats), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
+
+// FormatBytes renders a byte count with binary units
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/history"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exportScreen asks where and how to write the current results
+type exportScreen struct {
+	path      textinput.Model
+	format    string
+	algorithm string
+	// confirm is set after a first enter on an existing file
+	confirm bool
+	err     error
+}
+
+func newExportScreen(algorithm string, results []hasher.Result) exportScreen {
+	format := history.FormatChecksums
+	for _, r := range results {
+		if r.Error == nil && !r.IsFile {
+			// Checksum files only list files
+			format = history.FormatJSON
+			break
+		}
+	}
+
+	pi := textinput.New()
+	pi.CharLimit = 4096
+	pi.Width = 60
+	pi.SetValue(exportName(algorithm, format))
+	pi.Focus()
+
+	return exportScreen{path: pi, format: format, algorithm: algorithm}
+}
+
+// exportName suggests a file name, e.g. SHA256SUMS for a checksum file
+func exportName(algorithm, format string) string {
+	switch format {
+	case history.FormatChecksums:
+		return strings.ToUpper(strings.ReplaceAll(algorithm, "-", "")) + "SUMS"
+	case history.FormatCSV:
+		return "hashes.csv"
+	default:
+		return "hashes.json"
+	}
+}
+
+func (m Model) handleExport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	x := &m.export
+	x.err = nil
+
+	switch msg.String() {
+	case "esc":
+		m.state = StateResults
+		return m, nil
+	case "tab", "shift+tab":
+		// Follow the format with the suggested name unless it was edited
+		suggested := x.path.Value() == exportName(x.algorithm, x.format)
+		x.format = next(history.Formats, x.format)
+		if suggested {
+			x.path.SetValue(exportName(x.algorithm, x.format))
+			x.path.CursorEnd()
+		}
+		x.confirm = false
+		return m, nil
+	case "enter":
+		path := strings.TrimSpace(x.path.Value())
+		if path == "" {
+			return m, nil
+		}
+		if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(path, "~/") {
+			path = filepath.Join(home, path[2:])
+		}
+		if _, err := os.Stat(path); err == nil && !x.confirm {
+			x.confirm = true
+			return m, nil
+		}
+		n, err := exportResults(path, x.format, m.opts.Algorithm, m.results)
+		if err != nil {
+			x.err = err
+			x.confirm = false
+			return m, nil
+		}
+		m.status, m.statusErr = fmt.Sprintf("wrote %d results to %s", n, path), false
+		m.state = StateResults
+		return m, nil
+	}
+
+	x.confirm = false
+	var cmd tea.Cmd
+	x.path, cmd = x.path.Update(msg)
+	return m, cmd
+}
+
+// exportResults writes the successful results and returns how many were
+// written. Strings are written as hashed; inputs to password hashes are
+// left out.
+func exportResults(path, format, algorithm string, results []hasher.Result) (int, error) {
+	var entries []history.Entry
+	for _, r := range results {
+		if r.Error != nil || (format == history.FormatChecksums && !r.IsFile) {
+			continue
+		}
+		entries = append(entries, exportEntry(algorithm, r))
+	}
+	if len(entries) == 0 {
+		if format == history.FormatChecksums {
+			return 0, errors.New("checksum files only list files; choose json or csv")
+		}
+		return 0, errors.New("no successful results to export")
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	err = history.Export(f, entries, format)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return len(entries), err
+}
+
+func (m Model) viewExport() string {
+	var s strings.Builder
+	x := m.export
+
+	s.WriteString(LogoStyle.Render("hashctl"))
+	s.WriteString(LogoAccent.Render(" ⟡ "))
+	s.WriteString(LabelStyle.Render("EXPORT"))
+	s.WriteString("\n\n")
+
+	s.WriteString(SubtitleStyle.Render(fmt.Sprintf("export %d results as:", len(m.results))))
+	s.WriteString("\n\n")
+	for _, f := range history.Formats {
+		label := map[string]string{
+			history.FormatChecksums: "checksum file",
+			history.FormatJSON:      "JSON",
+			history.FormatCSV:       "CSV",
+		}[f]
+		if f == x.format {
+			s.WriteString(SelectedStyle.Render("◉ " + label))
+		} else {
+			s.WriteString(UnselectedStyle.Render("○ " + label))
+		}
+		s.WriteString("   ")
+	}
+	s.WriteString("\n\n")
+
+	s.WriteString(SubtitleStyle.Render("path:"))
+	s.WriteString("\n\n")
+	s.WriteString(InputStyle.Render(x.path.View()))
+	s.WriteString("\n")
+
+	switch {
+	case x.err != nil:
+		s.WriteString("\n")
+		s.WriteString(ErrorStyle.Render("✗ " + x.err.Error()))
+		s.WriteString("\n")
+	case x.confirm:
+		s.WriteString("\n")
+		s.WriteString(WarningStyle.Render("⚠ the file exists; press enter again to overwrite"))
+		s.WriteString("\n")
+	}
+
+	s.WriteString(HelpStyle.Render("enter write • tab format • esc back"))
+
+	return s.String()
+}
+
+// exportEntry describes a result for export, keeping file paths as given
+// so checksum files stay relative
+func exportEntry(algorithm string, r hasher.Result) history.Entry {
+	e := history.Entry{
+		Time:      time.Now().UTC(),
+		Algorithm: algorithm,
+		Kind:      history.KindString,
+		Input:     r.Input,
+		Digest:    r.Hash,
+		Size:      r.Size,
+	}
+	alg, _ := hasher.GetAlgorithm(algorithm)
+	switch {
+	case r.IsFile:
+		e.Kind = history.KindFile
+	case alg.IsPasswordHash:
+		e.Kind = history.KindPassword
+		e.Input, e.Size = "", 0
+	}
+	return e
+}
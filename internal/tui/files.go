@@ -0,0 +1,456 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filePicker browses directories and collects files and folders to hash
+type filePicker struct {
+	dir     string
+	entries []os.DirEntry
+	err     error
+
+	cursor int
+	offset int
+
+	showHidden bool
+	filter     textinput.Model
+	filtering  bool
+
+	// selected holds absolute paths; order keeps them in selection order
+	selected map[string]bool
+	order    []string
+}
+
+// Messages streamed while files are hashed
+type filesListedMsg struct {
+	total int
+}
+
+type fileResultMsg struct {
+	result  hasher.Result
+	verdict verdict
+}
+
+type filesDoneMsg struct{}
+
+func newFilePicker() filePicker {
+	fi := textinput.New()
+	fi.Prompt = "/"
+	fi.Placeholder = "filter"
+	fi.CharLimit = 256
+	fi.Width = 40
+
+	p := filePicker{filter: fi, selected: make(map[string]bool)}
+	p.dir, p.err = os.Getwd()
+	if p.err == nil {
+		p.load()
+	}
+	return p
+}
+
+// load reads the current directory, folders first
+func (p *filePicker) load() {
+	p.entries, p.err = os.ReadDir(p.dir)
+	sort.SliceStable(p.entries, func(i, j int) bool {
+		di, dj := p.isDir(p.entries[i]), p.isDir(p.entries[j])
+		if di != dj {
+			return di
+		}
+		return p.entries[i].Name() < p.entries[j].Name()
+	})
+	p.cursor, p.offset = 0, 0
+}
+
+// isDir reports whether e is a directory, following symlinks
+func (p filePicker) isDir(e os.DirEntry) bool {
+	if e.IsDir() {
+		return true
+	}
+	if e.Type()&os.ModeSymlink == 0 {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(p.dir, e.Name()))
+	return err == nil && info.IsDir()
+}
+
+// visible returns the entries left after the hidden and name filters
+func (p filePicker) visible() []os.DirEntry {
+	filter := strings.ToLower(p.filter.Value())
+	var out []os.DirEntry
+	for _, e := range p.entries {
+		if !p.showHidden && strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(e.Name()), filter) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// current returns the entry under the cursor
+func (p filePicker) current() (os.DirEntry, bool) {
+	entries := p.visible()
+	if p.cursor < 0 || p.cursor >= len(entries) {
+		return nil, false
+	}
+	return entries[p.cursor], true
+}
+
+// open changes into dir, placing the cursor on focus when it is listed
+func (p *filePicker) open(dir, focus string) {
+	p.dir = dir
+	p.filter.Reset()
+	p.load()
+	for i, e := range p.visible() {
+		if e.Name() == focus {
+			p.cursor = i
+		}
+	}
+}
+
+func (p *filePicker) toggle(path string) {
+	if p.selected[path] {
+		delete(p.selected, path)
+		for i, s := range p.order {
+			if s == path {
+				p.order = append(p.order[:i], p.order[i+1:]...)
+				break
+			}
+		}
+		return
+	}
+	p.selected[path] = true
+	p.order = append(p.order, path)
+}
+
+// paths returns the selection, relative to the working directory where
+// possible so results stay short
+func (p filePicker) paths() []string {
+	wd, _ := os.Getwd()
+	out := make([]string, 0, len(p.order))
+	for _, path := range p.order {
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		out = append(out, path)
+	}
+	return out
+}
+
+// pickerRows is how many entries fit on screen
+func (m Model) pickerRows() int {
+	return max(m.height-12, 5)
+}
+
+func (m Model) handleFilePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.picker
+	entries := p.visible()
+
+	if p.filtering {
+		switch msg.String() {
+		case "esc":
+			p.filter.Reset()
+			p.filter.Blur()
+			p.filtering = false
+			p.cursor, p.offset = 0, 0
+			return m, nil
+		case "enter":
+			p.filter.Blur()
+			p.filtering = false
+			return m, nil
+		case "up", "down":
+		default:
+			var cmd tea.Cmd
+			p.filter, cmd = p.filter.Update(msg)
+			p.cursor, p.offset = 0, 0
+			return m, cmd
+		}
+	}
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc":
+		if p.filter.Value() != "" {
+			p.filter.Reset()
+			p.cursor, p.offset = 0, 0
+			return m, nil
+		}
+		m.state = StateInputMode
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(entries)-1 {
+			p.cursor++
+		}
+	case "pgup":
+		p.cursor = max(p.cursor-m.pickerRows(), 0)
+	case "pgdown":
+		p.cursor = max(min(p.cursor+m.pickerRows(), len(entries)-1), 0)
+	case "home", "g":
+		p.cursor = 0
+	case "end", "G":
+		p.cursor = max(len(entries)-1, 0)
+	case "right", "l":
+		if e, ok := p.current(); ok && p.isDir(e) {
+			p.open(filepath.Join(p.dir, e.Name()), "")
+		}
+	case "left", "h", "backspace":
+		if parent := filepath.Dir(p.dir); parent != p.dir {
+			p.open(parent, filepath.Base(p.dir))
+		}
+	case " ":
+		if e, ok := p.current(); ok {
+			p.toggle(filepath.Join(p.dir, e.Name()))
+			if p.cursor < len(entries)-1 {
+				p.cursor++
+			}
+		}
+	case "a":
+		all := len(entries) > 0
+		for _, e := range entries {
+			all = all && p.selected[filepath.Join(p.dir, e.Name())]
+		}
+		for _, e := range entries {
+			if path := filepath.Join(p.dir, e.Name()); p.selected[path] == all {
+				p.toggle(path)
+			}
+		}
+	case ".":
+		p.showHidden = !p.showHidden
+		p.cursor, p.offset = 0, 0
+	case "/":
+		p.filtering = true
+		p.filter.Focus()
+		return m, textinput.Blink
+	case "t":
+		m.textInput.Reset()
+		m.textInput.Focus()
+		m.resetExpect()
+		m.state = StateTextInput
+		return m, textinput.Blink
+	case "enter":
+		if len(p.order) == 0 {
+			e, ok := p.current()
+			if !ok {
+				return m, nil
+			}
+			if p.isDir(e) {
+				p.open(filepath.Join(p.dir, e.Name()), "")
+				return m, nil
+			}
+			p.toggle(filepath.Join(p.dir, e.Name()))
+		}
+		m.files = p.paths()
+		return m, m.startHashFiles()
+	}
+
+	// Keep the cursor on screen
+	rows := m.pickerRows()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+rows {
+		p.offset = p.cursor - rows + 1
+	}
+	return m, nil
+}
+
+// startHashFiles switches to the hashing screen and streams results for m.files
+func (m *Model) startHashFiles() tea.Cmd {
+	m.state = StateHashing
+	m.isHashing = true
+	m.hashStart = time.Now()
+	m.results, m.verdicts = nil, nil
+	m.hashTotal = 0
+	return tea.Batch(m.spinner.Tick, m.doHashFiles())
+}
+
+// doHashFiles expands folders in m.files and sends one message per result,
+// so the screen fills in as each file finishes
+func (m *Model) doHashFiles() tea.Cmd {
+	updates := make(chan tea.Msg, 64)
+	m.hashUpdates = updates
+	files, opts, expected := m.files, m.opts, m.expected
+
+	go func() {
+		defer close(updates)
+
+		var expanded []string
+		var failed []hasher.Result
+		seen := make(map[string]bool)
+		add := func(f string) {
+			if !seen[f] {
+				seen[f] = true
+				expanded = append(expanded, f)
+			}
+		}
+		for _, f := range files {
+			// Missing files are left to HashFiles, which reports the error
+			info, err := os.Stat(f)
+			if err != nil || !info.IsDir() {
+				add(f)
+				continue
+			}
+			walked, err := hasher.WalkFiles(f)
+			if err != nil {
+				failed = append(failed, hasher.Result{Input: f, Error: err, IsFile: true})
+			}
+			for _, w := range walked {
+				add(w)
+			}
+		}
+
+		updates <- filesListedMsg{total: len(expanded) + len(failed)}
+		for _, r := range failed {
+			updates <- fileResultMsg{result: r}
+		}
+		hasher.HashFiles(expanded, opts, func(r hasher.Result) {
+			updates <- fileResultMsg{result: r, verdict: judge(expected, r, opts)}
+		})
+	}()
+
+	return m.waitForFileResult()
+}
+
+func (m Model) waitForFileResult() tea.Cmd {
+	updates := m.hashUpdates
+	return func() tea.Msg {
+		msg, ok := <-updates
+		if !ok {
+			return filesDoneMsg{}
+		}
+		return msg
+	}
+}
+
+func (m Model) viewFilePicker() string {
+	var s strings.Builder
+	p := m.picker
+
+	s.WriteString(LogoStyle.Render("hashctl"))
+	s.WriteString(LogoAccent.Render(" ⟡ "))
+	s.WriteString(LabelStyle.Render(m.selectedAlgo.Name))
+	s.WriteString("\n\n")
+
+	s.WriteString(ValueStyle.Render(truncate(p.dir, max(m.width-8, 20))))
+	s.WriteString("\n")
+	status := fmt.Sprintf("%d selected", len(p.order))
+	if p.showHidden {
+		status += " • showing hidden"
+	}
+	s.WriteString(MutedStyle.Render(status))
+	s.WriteString("\n")
+	if p.filtering || p.filter.Value() != "" {
+		s.WriteString(InputStyle.Render(p.filter.View()))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	entries := p.visible()
+	switch {
+	case p.err != nil:
+		s.WriteString(ErrorStyle.Render("✗ " + p.err.Error()))
+		s.WriteString("\n")
+	case len(entries) == 0:
+		s.WriteString(DimStyle.Render("  (empty)"))
+		s.WriteString("\n")
+	}
+
+	end := min(p.offset+m.pickerRows(), len(entries))
+	for i := p.offset; i < end; i++ {
+		e := entries[i]
+		if i == p.cursor {
+			s.WriteString(Cursor())
+		} else {
+			s.WriteString(NoCursor())
+		}
+
+		if p.selected[filepath.Join(p.dir, e.Name())] {
+			s.WriteString(SuccessStyle.Render("◉ "))
+		} else {
+			s.WriteString(DimStyle.Render("○ "))
+		}
+
+		name := e.Name()
+		switch {
+		case p.isDir(e):
+			name += "/"
+			if i == p.cursor {
+				s.WriteString(SelectedStyle.Render(name))
+			} else {
+				s.WriteString(FileStyle.Render(name))
+			}
+		case i == p.cursor:
+			s.WriteString(SelectedStyle.Render(name))
+		default:
+			s.WriteString(UnselectedStyle.Render(name))
+		}
+		if info, err := e.Info(); err == nil && info.Mode().IsRegular() {
+			s.WriteString(DimStyle.Render("  " + FormatBytes(info.Size())))
+		}
+		s.WriteString("\n")
+	}
+	if end < len(entries) {
+		s.WriteString(DimStyle.Render(fmt.Sprintf("  … %d more", len(entries)-end)))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(HelpStyle.Render("space select • a all • enter hash • →/← open/up • / filter • . hidden • t type path • esc back"))
+
+	return s.String()
+}
+
+// viewFileResults lists the most recent results compactly while hashing,
+// showing at most limit of them
+func (m Model) viewFileResults(limit int) string {
+	var s strings.Builder
+
+	start := max(len(m.results)-limit, 0)
+	if start > 0 {
+		s.WriteString(DimStyle.Render(fmt.Sprintf("  … %d more", start)))
+		s.WriteString("\n")
+	}
+	for i := start; i < len(m.results); i++ {
+		r := m.results[i]
+		s.WriteString(NoCursor())
+		if r.Error != nil {
+			s.WriteString(ErrorStyle.Render("✗ " + r.Input))
+			s.WriteString("\n")
+			s.WriteString(MutedStyle.Render("    " + r.Error.Error()))
+			s.WriteString("\n")
+			continue
+		}
+		s.WriteString(SuccessStyle.Render("✓ "))
+		s.WriteString(ValueStyle.Render(r.Input))
+		if m.expected != nil {
+			v := m.verdictOf(i)
+			s.WriteString("  ")
+			if v.err != nil {
+				s.WriteString(ErrorStyle.Render("✗ " + v.err.Error()))
+			} else {
+				s.WriteString(Verdict(v.match, ""))
+			}
+		}
+		s.WriteString("\n")
+		s.WriteString("    ")
+		s.WriteString(HashStyle.Render(r.Hash))
+		s.WriteString("\n")
+	}
+	return s.String()
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/fingerprint"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// visualKinds are the fingerprints v cycles through; "" hides them
+var visualKinds = append([]string{""}, fingerprint.Kinds...)
+
+// Fingerprint renders a hex digest as one of fingerprint.Kinds, laid out
+// in rows short enough to read aloud
+func Fingerprint(kind, algorithm, digest string) (string, error) {
+	b, err := fingerprint.Decode(digest)
+	if err != nil {
+		return "", err
+	}
+
+	var s strings.Builder
+	switch kind {
+	case fingerprint.KindRandomart:
+		art := fingerprint.Randomart(b, strings.ToUpper(algorithm), fmt.Sprintf("%d bits", 8*len(b)))
+		for i, line := range strings.Split(art, "\n") {
+			if i > 0 {
+				s.WriteString("\n")
+			}
+			s.WriteString(DimStyle.Render(line[:1]))
+			s.WriteString(HashStyle.Render(line[1 : len(line)-1]))
+			s.WriteString(DimStyle.Render(line[len(line)-1:]))
+		}
+	case fingerprint.KindBlocks:
+		if !ColorEnabled() {
+			return "", errors.New("blocks need a colour terminal; try randomart or words")
+		}
+		for i, c := range fingerprint.Blocks(b) {
+			if i > 0 && i%16 == 0 {
+				s.WriteString("\n")
+			}
+			s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(fingerprint.BlockColors[c])).Render("██"))
+		}
+	case fingerprint.KindEmoji:
+		for i, e := range fingerprint.Emoji(b) {
+			switch {
+			case i > 0 && i%8 == 0:
+				s.WriteString("\n")
+			case i > 0:
+				s.WriteString(" ")
+			}
+			s.WriteString(e)
+		}
+	case fingerprint.KindWords:
+		words := fingerprint.Words(b)
+		for i, w := range words {
+			if i > 0 && i%4 == 0 {
+				s.WriteString("\n")
+			}
+			// Columns line up; the last word of a row is not padded
+			if i%4 < 3 && i < len(words)-1 {
+				w = fmt.Sprintf("%-12s ", w)
+			}
+			s.WriteString(ValueStyle.Render(w))
+		}
+	default:
+		return "", fmt.Errorf("unknown fingerprint %q (use %s)", kind, strings.Join(fingerprint.Kinds, ", "))
+	}
+	return s.String(), nil
+}
+
+// ColorEnabled reports whether output is drawn in colour: it goes to a
+// terminal and NO_COLOR is not set
+func ColorEnabled() bool {
+	return !NoColor() && lipgloss.ColorProfile() != termenv.Ascii
+}
+
+// viewFingerprint shows the chosen fingerprint of a result, or why there
+// is none
+func (m Model) viewFingerprint(digest, indent string) string {
+	fp, err := Fingerprint(m.visual, m.opts.Algorithm, digest)
+	if err != nil {
+		return indent + MutedStyle.Render(m.visual+": "+err.Error()) + "\n"
+	}
+
+	var s strings.Builder
+	s.WriteString(indent + DimStyle.Render(m.visual+" • v next") + "\n")
+	for _, line := range strings.Split(fp, "\n") {
+		s.WriteString(indent + line + "\n")
+	}
+	return s.String()
+}
+
+// fingerprintHeight is how many lines the fingerprint of the selected
+// result takes, so the table can make room for it
+func (m Model) fingerprintHeight() int {
+	r, ok := m.selectedResult()
+	if !ok || m.visual == "" || r.Error != nil {
+		return 0
+	}
+	return strings.Count(m.viewFingerprint(r.Hash, ""), "\n")
+}
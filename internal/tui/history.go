@@ -0,0 +1,310 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/fuzzy"
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/history"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// historyScreen lists earlier digests with fuzzy search
+type historyScreen struct {
+	entries []history.Entry
+	matches []int // indexes into entries, best match first
+	err     error
+
+	search    textinput.Model
+	searching bool
+
+	cursor int
+	offset int
+}
+
+type historySavedMsg struct {
+	err error
+}
+
+type historyVerifiedMsg struct {
+	entry history.Entry
+	match bool
+	err   error
+}
+
+func newHistoryScreen(store *history.Store) historyScreen {
+	si := textinput.New()
+	si.Prompt = "/"
+	si.Placeholder = "search"
+	si.CharLimit = 256
+	si.Width = 40
+
+	h := historyScreen{search: si}
+	if store != nil {
+		h.entries, h.err = store.Load()
+	}
+	h.filter()
+	return h
+}
+
+// filter recomputes the matches for the search query
+func (h *historyScreen) filter() {
+	items := make([]string, len(h.entries))
+	for i, e := range h.entries {
+		items[i] = e.Algorithm + " " + e.Label() + " " + e.Digest
+	}
+	h.matches = fuzzy.Filter(h.search.Value(), items)
+	h.cursor, h.offset = 0, 0
+}
+
+// selected returns the entry under the cursor
+func (h historyScreen) selected() (history.Entry, bool) {
+	if h.cursor < 0 || h.cursor >= len(h.matches) {
+		return history.Entry{}, false
+	}
+	return h.entries[h.matches[h.cursor]], true
+}
+
+// record saves successful results to the history in the background
+func (m Model) record(results []hasher.Result) tea.Cmd {
+	if m.history == nil {
+		return nil
+	}
+	var entries []history.Entry
+	for _, r := range results {
+		if r.Error != nil {
+			continue
+		}
+		if e, ok := history.FromResult(m.opts.Algorithm, r); ok {
+			entries = append(entries, e)
+		}
+	}
+	store := m.history
+	return func() tea.Msg {
+		return historySavedMsg{err: store.Add(entries...)}
+	}
+}
+
+func (m Model) historyRows() int {
+	return max(m.height-16, 5)
+}
+
+func (m Model) handleHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	h := &m.hist
+	m.status, m.statusErr = "", false
+
+	if h.searching {
+		switch msg.String() {
+		case "esc":
+			h.search.Reset()
+			h.search.Blur()
+			h.searching = false
+			h.filter()
+			return m, nil
+		case "enter":
+			h.search.Blur()
+			h.searching = false
+			return m, nil
+		case "up", "down":
+		default:
+			var cmd tea.Cmd
+			h.search, cmd = h.search.Update(msg)
+			h.filter()
+			return m, cmd
+		}
+	}
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc":
+		if h.search.Value() != "" {
+			h.search.Reset()
+			h.filter()
+			return m, nil
+		}
+		m.state = StateCategorySelect
+	case "up", "k":
+		if h.cursor > 0 {
+			h.cursor--
+		}
+	case "down", "j":
+		if h.cursor < len(h.matches)-1 {
+			h.cursor++
+		}
+	case "home", "g":
+		h.cursor = 0
+	case "end", "G":
+		h.cursor = max(len(h.matches)-1, 0)
+	case "/":
+		h.searching = true
+		return m, h.search.Focus()
+	case "c":
+		if e, ok := h.selected(); ok {
+			return m, copyToClipboard(e.Digest)
+		}
+	case "v":
+		if e, ok := h.selected(); ok {
+			if !m.policy.Allowed(e.Algorithm) {
+				m.status, m.statusErr = e.Algorithm+" is forbidden by policy "+m.policy.Name, true
+				return m, nil
+			}
+			opts := m.opts
+			return m, func() tea.Msg {
+				match, err := history.Verify(e, opts)
+				return historyVerifiedMsg{entry: e, match: match, err: err}
+			}
+		}
+	case "enter":
+		if e, ok := h.selected(); ok {
+			return m.rehash(e)
+		}
+	}
+
+	rows := m.historyRows()
+	if h.cursor < h.offset {
+		h.offset = h.cursor
+	}
+	if h.cursor >= h.offset+rows {
+		h.offset = h.cursor - rows + 1
+	}
+	return m, nil
+}
+
+// rehash hashes an entry's input again on the results screen, compared
+// against the recorded digest
+func (m Model) rehash(e history.Entry) (tea.Model, tea.Cmd) {
+	alg, ok := hasher.GetAlgorithm(e.Algorithm)
+	switch {
+	case !ok:
+		m.status, m.statusErr = "unknown algorithm "+e.Algorithm, true
+		return m, nil
+	case !m.policy.Allowed(e.Algorithm):
+		m.status, m.statusErr = e.Algorithm+" is forbidden by policy "+m.policy.Name, true
+		return m, nil
+	case !e.Stored():
+		m.status, m.statusErr = "the input was not stored, so it cannot be hashed again", true
+		return m, nil
+	}
+
+	expected, err := hasher.ParseExpected(e.Algorithm + ":" + e.Digest)
+	if err != nil {
+		m.status, m.statusErr = err.Error(), true
+		return m, nil
+	}
+	m.selectedAlgo = alg
+	m.opts.Algorithm = e.Algorithm
+	m.expected = &expected
+
+	if e.Kind == history.KindFile {
+		m.inputMode = InputModeFile
+		m.files = []string{e.Input}
+		return m, m.startHashFiles()
+	}
+	m.inputMode = InputModeString
+	m.state = StateHashing
+	m.isHashing = true
+	m.hashStart = time.Now()
+	return m, tea.Batch(m.spinner.Tick, m.doHashString(e.Input))
+}
+
+func (m Model) viewHistory() string {
+	var s strings.Builder
+	h := m.hist
+
+	s.WriteString(LogoStyle.Render("hashctl"))
+	s.WriteString(LogoAccent.Render(" ⟡ "))
+	s.WriteString(LabelStyle.Render("HISTORY"))
+	s.WriteString("\n\n")
+
+	if m.history == nil {
+		s.WriteString(MutedStyle.Render("history is turned off"))
+		s.WriteString("\n")
+		s.WriteString(HelpStyle.Render("esc back • q quit"))
+		return s.String()
+	}
+
+	s.WriteString(MutedStyle.Render(fmt.Sprintf("%d of %d entries", len(h.matches), len(h.entries))))
+	s.WriteString("\n")
+	if h.searching || h.search.Value() != "" {
+		s.WriteString(InputStyle.Render(h.search.View()))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	switch {
+	case h.err != nil:
+		s.WriteString(ErrorStyle.Render("✗ " + h.err.Error()))
+		s.WriteString("\n")
+	case len(h.entries) == 0:
+		s.WriteString(DimStyle.Render("  nothing hashed yet"))
+		s.WriteString("\n")
+	case len(h.matches) == 0:
+		s.WriteString(DimStyle.Render("  no matches"))
+		s.WriteString("\n")
+	}
+
+	labelWidth := max(m.width-36, 16)
+	end := min(h.offset+m.historyRows(), len(h.matches))
+	for i := h.offset; i < end; i++ {
+		e := h.entries[h.matches[i]]
+		if i == h.cursor {
+			s.WriteString(Cursor())
+		} else {
+			s.WriteString(NoCursor())
+		}
+		s.WriteString(DimStyle.Render(fmt.Sprintf("%-9s", age(e.Time))))
+		s.WriteString(LabelStyle.Render(fmt.Sprintf("%-13s", e.Algorithm)))
+		label := truncate(e.Label(), labelWidth)
+		if i == h.cursor {
+			s.WriteString(SelectedStyle.Render(label))
+		} else {
+			s.WriteString(UnselectedStyle.Render(label))
+		}
+		s.WriteString("\n")
+	}
+
+	if e, ok := h.selected(); ok {
+		s.WriteString("\n")
+		s.WriteString(HashStyle.Render(e.Digest))
+		s.WriteString("\n")
+		s.WriteString(DimStyle.Render(e.Time.Local().Format("2006-01-02 15:04:05") + " • " + FormatBytes(e.Size)))
+		s.WriteString("\n")
+	}
+	if m.status != "" {
+		s.WriteString("\n")
+		s.WriteString(m.viewStatus())
+		s.WriteString("\n")
+	}
+
+	s.WriteString(HelpStyle.Render("↑/↓ select • / search • enter hash again • v verify • c copy • esc back"))
+
+	return s.String()
+}
+
+// viewStatus renders the outcome of the last action
+func (m Model) viewStatus() string {
+	if m.statusErr {
+		return ErrorStyle.Render("✗ " + m.status)
+	}
+	return SuccessStyle.Render("✓ " + m.status)
+}
+
+// age describes how long ago t was
+func age(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+	return t.Local().Format("2006-01-02")
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m Model) handleIdentifyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.textInput.Reset()
+		m.state = StateCategorySelect
+		return m, nil
+	case "enter":
+		input := strings.TrimSpace(m.textInput.Value())
+		if input == "" {
+			return m, nil
+		}
+		m.identified = hasher.Identify(input)
+		m.state = StateIdentifyResults
+		return m, nil
+	default:
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		return m, cmd
+	}
+}
+
+func (m Model) handleIdentifyResults(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc", "r":
+		m.textInput.Reset()
+		m.state = StateCategorySelect
+	case "n", "i":
+		m.textInput.Reset()
+		m.textInput.Focus()
+		m.state = StateIdentifyInput
+	}
+	return m, nil
+}
+
+func (m Model) viewIdentifyInput() string {
+	var s strings.Builder
+
+	s.WriteString(LogoStyle.Render("hashctl"))
+	s.WriteString(LogoAccent.Render(" ⟡ "))
+	s.WriteString(LabelStyle.Render("IDENTIFY"))
+	s.WriteString("\n\n")
+
+	s.WriteString(SubtitleStyle.Render("paste a hash:"))
+	s.WriteString("\n\n")
+	s.WriteString(InputStyle.Render(m.textInput.View()))
+	s.WriteString("\n\n")
+
+	s.WriteString(HelpStyle.Render("enter identify • esc back"))
+
+	return s.String()
+}
+
+func (m Model) viewIdentifyResults() string {
+	var s strings.Builder
+	id := m.identified
+
+	s.WriteString(LogoStyle.Render("hashctl"))
+	s.WriteString(LogoAccent.Render(" ⟡ "))
+	s.WriteString(LabelStyle.Render("IDENTIFY"))
+	s.WriteString("\n\n")
+
+	s.WriteString(MutedStyle.Render(truncate(id.Input, max(m.width-8, 20))))
+	s.WriteString("\n")
+	encoding := id.Encoding
+	if id.Bytes > 0 {
+		encoding += fmt.Sprintf(" • %d bytes", id.Bytes)
+	}
+	s.WriteString(DimStyle.Render(encoding))
+	s.WriteString("\n\n")
+
+	if len(id.Candidates) == 0 {
+		s.WriteString(ErrorStyle.Render("✗ no matching algorithm"))
+		s.WriteString("\n")
+	}
+	for i, c := range id.Candidates {
+		name := fmt.Sprintf("%-14s", c.Name)
+		if i == 0 {
+			s.WriteString(Cursor())
+			s.WriteString(SelectedStyle.Render(name))
+		} else {
+			s.WriteString(NoCursor())
+			s.WriteString(UnselectedStyle.Render(name))
+		}
+
+		likelihood := fmt.Sprintf(" %-9s ", c.Likelihood())
+		switch {
+		case c.Score >= 70:
+			s.WriteString(SuccessStyle.Render(likelihood))
+		case c.Score >= 40:
+			s.WriteString(WarningStyle.Render(likelihood))
+		default:
+			s.WriteString(DimStyle.Render(likelihood))
+		}
+		s.WriteString(DimStyle.Render(c.Reason))
+		if c.Algorithm != "" && !m.policy.Allowed(c.Algorithm) {
+			s.WriteString(ErrorStyle.Render("  forbidden"))
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString(HelpStyle.Render("n identify another • esc back • q quit"))
+
+	return s.String()
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// rawInput returns the text typed on the string screen
+func (m Model) rawInput() string {
+	if m.multiline {
+		return m.textArea.Value()
+	}
+	return m.textInput.Value()
+}
+
+// stringInput returns the exact bytes to hash after the line ending and
+// decoder are applied
+func (m Model) stringInput() (string, error) {
+	s := m.rawInput()
+	if m.multiline {
+		var err error
+		if s, err = hasher.ApplyLineEnding(s, m.lineEnding); err != nil {
+			return "", err
+		}
+	}
+	data, err := hasher.DecodeInput(s, m.decoder)
+	return string(data), err
+}
+
+// focusInput focuses the single-line or multi-line editor
+func (m *Model) focusInput() tea.Cmd {
+	m.expectInput.Blur()
+	if m.multiline {
+		m.textInput.Blur()
+		return m.textArea.Focus()
+	}
+	m.textArea.Blur()
+	return m.textInput.Focus()
+}
+
+// next returns the value after current in values, wrapping around
+func next(values []string, current string) string {
+	for i, v := range values {
+		if v == current {
+			return values[(i+1)%len(values)]
+		}
+	}
+	return values[0]
+}
+
+// viewInputStatus shows how the string will be read and its exact size
+func (m Model) viewInputStatus() string {
+	status := m.decoder
+	if m.multiline {
+		status += " • " + m.lineEnding
+	}
+
+	input, err := m.stringInput()
+	if err != nil {
+		return MutedStyle.Render(status+" • ") + ErrorStyle.Render("✗ "+err.Error())
+	}
+	return MutedStyle.Render(fmt.Sprintf("%s • %d bytes", status, len(input)))
+}
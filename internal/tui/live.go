@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// liveDelay debounces live hashing so a burst of keystrokes hashes once
+const liveDelay = 150 * time.Millisecond
+
+// liveAlgorithms are shown beside the selected algorithm in the side-by-side view
+var liveAlgorithms = []string{
+	"crc32", "md5", "sha1", "sha256", "sha512", "sha3-256", "blake2b-256", "blake2s-256",
+}
+
+// liveDigest is one algorithm's digest of the current input
+type liveDigest struct {
+	algorithm string
+	hash      string
+	err       error
+}
+
+type liveTickMsg struct {
+	seq int
+}
+
+type liveHashMsg struct {
+	seq     int
+	digests []liveDigest
+}
+
+// liveEnabled reports whether the string screen hashes as you type.
+// Password hashes are deliberately slow, so they never run live.
+func (m Model) liveEnabled() bool {
+	return m.live && m.inputMode == InputModeString && !m.selectedAlgo.IsPasswordHash
+}
+
+// liveKeys returns the algorithms to hash live, selected one first
+func (m Model) liveKeys() []string {
+	keys := []string{m.opts.Algorithm}
+	if !m.liveMulti {
+		return keys
+	}
+	for _, key := range liveAlgorithms {
+		if key != m.opts.Algorithm && m.policy.Allowed(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// scheduleLive restarts the debounce timer after the input changed
+func (m *Model) scheduleLive() tea.Cmd {
+	m.liveSeq++
+	if !m.liveEnabled() {
+		m.liveDigests = nil
+		return nil
+	}
+	seq := m.liveSeq
+	return tea.Tick(liveDelay, func(time.Time) tea.Msg {
+		return liveTickMsg{seq: seq}
+	})
+}
+
+// doLiveHash hashes the current input with every live algorithm
+func (m Model) doLiveHash(seq int) tea.Cmd {
+	input, err := m.stringInput()
+	if err != nil {
+		return nil
+	}
+	keys := m.liveKeys()
+	opts := m.opts
+	return func() tea.Msg {
+		digests := make([]liveDigest, 0, len(keys))
+		for _, key := range keys {
+			opts.Algorithm = key
+			r := hasher.HashString(input, opts)
+			digests = append(digests, liveDigest{algorithm: key, hash: r.Hash, err: r.Error})
+		}
+		return liveHashMsg{seq: seq, digests: digests}
+	}
+}
+
+func (m Model) viewLive() string {
+	var s strings.Builder
+
+	if m.selectedAlgo.IsPasswordHash {
+		s.WriteString(DimStyle.Render("live hashing is off for password hashes"))
+		return s.String()
+	}
+	if !m.live {
+		s.WriteString(DimStyle.Render("live hashing off"))
+		return s.String()
+	}
+	input, err := m.stringInput()
+	if m.rawInput() == "" || err != nil || len(m.liveDigests) == 0 {
+		s.WriteString(DimStyle.Render("digest appears as you type"))
+		return s.String()
+	}
+
+	if !m.liveMulti {
+		d := m.liveDigests[0]
+		if d.err != nil {
+			s.WriteString(ErrorStyle.Render("✗ " + d.err.Error()))
+			return s.String()
+		}
+		s.WriteString(HashStyle.Render(d.hash))
+		if raw := strings.TrimSpace(m.expectInput.Value()); raw != "" {
+			// Password hashes are checked once hashing is done, not per keystroke
+			if e, err := hasher.ParseExpected(raw); err == nil && e.Digest != nil {
+				match, err := e.Match(hasher.Result{Input: input, Hash: d.hash}, m.opts)
+				if err == nil {
+					s.WriteString("\n")
+					s.WriteString(Verdict(match, ""))
+				}
+			}
+		}
+		return s.String()
+	}
+
+	width := max(m.width-24, 16)
+	for i, d := range m.liveDigests {
+		name := fmt.Sprintf("%-13s", d.algorithm)
+		if i == 0 {
+			s.WriteString(SelectedStyle.Render(name))
+		} else {
+			s.WriteString(LabelStyle.Render(name))
+		}
+		s.WriteString(" ")
+		if d.err != nil {
+			s.WriteString(ErrorStyle.Render(d.err.Error()))
+		} else {
+			s.WriteString(HashStyle.Render(truncate(d.hash, width)))
+		}
+		if i < len(m.liveDigests)-1 {
+			s.WriteString("\n")
+		}
+	}
+	return s.String()
+}
@@ -2,11 +2,15 @@ package tui
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/history"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -21,6 +25,13 @@ const (
 	StateTextInput
 	StateHashing
 	StateResults
+	StateIdentifyInput
+	StateIdentifyResults
+	StateFilePicker
+	StateHistory
+	StateExport
+	StateSearch
+	StateSettings
 )
 
 // InputMode represents what we're hashing
@@ -45,17 +56,64 @@ type Model struct {
 	algorithmIndex int
 	selectedAlgo   hasher.Algorithm
 
+	// Search over all algorithms, recently used ones first
+	search algoSearch
+	recent []string
+
 	// Input
 	textInput textinput.Model
 	files     []string
+	picker    filePicker
+
+	// Multi-line, binary-safe string input
+	textArea   textarea.Model
+	multiline  bool
+	decoder    string
+	lineEnding string
+
+	// Live hashing of string input as it is typed
+	live        bool
+	liveMulti   bool
+	liveSeq     int
+	liveDigests []liveDigest
+
+	// Optional expected digest to compare results against
+	expectInput   textinput.Model
+	expectFocused bool
+	expected      *hasher.Expected
+	expectErr     error
 
 	// Hashing
-	spinner   spinner.Model
-	isHashing bool
-	hashStart time.Time
+	spinner     spinner.Model
+	isHashing   bool
+	hashStart   time.Time
+	hashTotal   int
+	hashElapsed time.Duration
+	hashUpdates <-chan tea.Msg
 
 	// Results
 	results []hasher.Result
+	// verdicts[i] compares results[i] with the expected digest
+	verdicts []verdict
+	table    resultsTable
+	export   exportScreen
+	visual   string // fingerprint kind shown with digests, "" for none
+
+	// Hash identification
+	identified hasher.Identification
+
+	// History of computed digests; nil when turned off
+	history    *history.Store
+	historyErr error
+	hist       historyScreen
+
+	// Saved settings, edited on the settings screen
+	settings Settings
+	prefs    settingsScreen
+
+	// Outcome of the last action, such as a copy
+	status    string
+	statusErr bool
 
 	// UI dimensions
 	width  int
@@ -63,42 +121,78 @@ type Model struct {
 	err    error
 
 	// Options
-	opts hasher.Options
+	opts   hasher.Options
+	policy hasher.Policy
 }
 
 // Messages
 type hashCompleteMsg struct {
-	results []hasher.Result
+	results  []hasher.Result
+	verdicts []verdict
 }
 
 type hashErrorMsg struct {
 	err error
 }
 
-// NewModel creates a new TUI model
-func NewModel() Model {
-	algs := hasher.GetSortedAlgorithms()
+// NewModel creates a new TUI model offering the algorithms the policy
+// allows, with defaults from settings. Results are recorded in store
+// unless it is nil.
+func NewModel(policy hasher.Policy, store *history.Store, settings Settings) Model {
+	var algs []hasher.Algorithm
+	for _, alg := range hasher.GetSortedAlgorithms() {
+		if policy.Allowed(getAlgorithmKey(alg.Name)) {
+			algs = append(algs, alg)
+		}
+	}
 
 	ti := textinput.New()
 	ti.Placeholder = "" // No placeholder - big empty input
 	ti.CharLimit = 10000
 	ti.Width = 70 // Bigger width for input
 
+	ei := textinput.New()
+	ei.Placeholder = "optional: paste the published hash"
+	ei.CharLimit = 512
+	ei.Width = 70
+
+	ta := textarea.New()
+	ta.CharLimit = 10000
+	ta.SetWidth(70)
+	ta.SetHeight(6)
+
 	s := spinner.New()
 	s.Spinner = spinner.MiniDot
 	s.Style = SpinnerStyle
 
-	return Model{
+	m := Model{
 		state:          StateCategorySelect,
 		categoryIndex:  0,
 		algorithms:     algs,
 		algorithmIndex: 0,
 		textInput:      ti,
+		expectInput:    ei,
+		textArea:       ta,
+		decoder:        hasher.DecodeText,
+		lineEnding:     hasher.LineEndingLF,
+		live:           true,
 		spinner:        s,
 		opts:           hasher.DefaultOptions(),
+		policy:         policy,
+		history:        store,
+		recent:         recentAlgorithms(store),
+		settings:       settings,
 		width:          80,
 		height:         24,
 	}
+	// The theme was validated when the settings were loaded
+	_ = m.applySettings()
+
+	// Start on the category of the default algorithm
+	if alg, ok := hasher.GetAlgorithm(settings.effective().Algorithm); ok {
+		m.categoryIndex = max(slices.Index(m.categories(), alg.Category), 0)
+	}
+	return m
 }
 
 // Init initializes the model
@@ -119,6 +213,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
+		if msg.String() == "ctrl+o" && m.state != StateSettings && m.state != StateHashing {
+			return m.openSettings()
+		}
 
 		switch m.state {
 		case StateCategorySelect:
@@ -134,6 +231,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case StateResults:
 			return m.handleResults(msg)
+		case StateIdentifyInput:
+			return m.handleIdentifyInput(msg)
+		case StateIdentifyResults:
+			return m.handleIdentifyResults(msg)
+		case StateFilePicker:
+			return m.handleFilePicker(msg)
+		case StateHistory:
+			return m.handleHistory(msg)
+		case StateExport:
+			return m.handleExport(msg)
+		case StateSearch:
+			return m.handleSearch(msg)
+		case StateSettings:
+			return m.handleSettings(msg)
 		}
 
 	case spinner.TickMsg:
@@ -146,7 +257,63 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case hashCompleteMsg:
 		m.isHashing = false
 		m.results = msg.results
+		m.verdicts = msg.verdicts
+		m.table = resultsTable{}
+		m.table.refresh(m)
+		m.state = StateResults
+		return m, m.record(msg.results)
+
+	case liveTickMsg:
+		if msg.seq == m.liveSeq && m.state == StateTextInput && m.liveEnabled() {
+			return m, m.doLiveHash(msg.seq)
+		}
+		return m, nil
+
+	case liveHashMsg:
+		if msg.seq == m.liveSeq {
+			m.liveDigests = msg.digests
+		}
+		return m, nil
+
+	case filesListedMsg:
+		m.hashTotal = msg.total
+		return m, m.waitForFileResult()
+
+	case fileResultMsg:
+		m.results = append(m.results, msg.result)
+		m.verdicts = append(m.verdicts, msg.verdict)
+		return m, m.waitForFileResult()
+
+	case filesDoneMsg:
+		m.isHashing = false
+		m.table = resultsTable{}
+		m.table.refresh(m)
+		m.hashElapsed = time.Since(m.hashStart)
+		m.hashUpdates = nil
 		m.state = StateResults
+		return m, m.record(m.results)
+
+	case historySavedMsg:
+		m.historyErr = msg.err
+		return m, nil
+
+	case historyVerifiedMsg:
+		switch {
+		case msg.err != nil:
+			m.status, m.statusErr = msg.err.Error(), true
+		case msg.match:
+			m.status, m.statusErr = "digest unchanged", false
+		default:
+			m.status, m.statusErr = "digest changed since "+msg.entry.Time.Local().Format("2006-01-02 15:04"), true
+		}
+		return m, nil
+
+	case clipboardMsg:
+		if msg.err != nil {
+			m.status, m.statusErr = "copy failed: "+msg.err.Error(), true
+		} else {
+			m.status, m.statusErr = "copied via "+msg.method, false
+		}
 		return m, nil
 
 	case hashErrorMsg:
@@ -160,11 +327,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleCategorySelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	categories := []hasher.Category{
-		hasher.CategoryChecksum,
-		hasher.CategoryFastHash,
-		hasher.CategoryPasswordHash,
-	}
+	categories := m.categories()
 
 	switch msg.String() {
 	case "q", "ctrl+c":
@@ -179,16 +342,24 @@ func (m Model) handleCategorySelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "enter", " ":
 		m.selectedCategory = categories[m.categoryIndex]
-		// Filter algorithms by category
-		allAlgs := hasher.GetSortedAlgorithms()
-		m.algorithms = []hasher.Algorithm{}
-		for _, alg := range allAlgs {
-			if alg.Category == m.selectedCategory {
-				m.algorithms = append(m.algorithms, alg)
-			}
-		}
-		m.algorithmIndex = 0
+		m.algorithms = m.algorithmsIn(m.selectedCategory)
+		// Start on the default algorithm when it is in this category
+		def := m.settings.effective().Algorithm
+		m.algorithmIndex = max(slices.IndexFunc(m.algorithms, func(a hasher.Algorithm) bool {
+			return getAlgorithmKey(a.Name) == def
+		}), 0)
 		m.state = StateAlgorithmSelect
+	case "/":
+		return m.openSearch()
+	case "i":
+		m.textInput.Reset()
+		m.textInput.Focus()
+		m.state = StateIdentifyInput
+		return m, textinput.Blink
+	case "h":
+		m.hist = newHistoryScreen(m.history)
+		m.status = ""
+		m.state = StateHistory
 	case "home", "g":
 		m.categoryIndex = 0
 	case "end", "G":
@@ -212,9 +383,9 @@ func (m Model) handleAlgorithmSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.algorithmIndex++
 		}
 	case "enter", " ":
-		m.selectedAlgo = m.algorithms[m.algorithmIndex]
-		m.opts.Algorithm = getAlgorithmKey(m.selectedAlgo.Name)
-		m.state = StateInputMode
+		return m.selectAlgorithm(m.algorithms[m.algorithmIndex]), nil
+	case "/":
+		return m.openSearch()
 	case "home", "g":
 		m.algorithmIndex = 0
 	case "end", "G":
@@ -233,55 +404,174 @@ func (m Model) handleInputMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.inputMode = InputModeString
 		m.textInput.Placeholder = "" // No placeholder
 		m.textInput.Reset()
-		m.textInput.Focus()
+		m.textArea.Reset()
+		m.resetExpect()
+		m.liveDigests = nil
 		m.state = StateTextInput
-		return m, textinput.Blink
+		return m, tea.Batch(m.focusInput(), textinput.Blink)
 	case "f", "2":
 		m.inputMode = InputModeFile
 		m.textInput.Placeholder = "" // No placeholder
 		m.textInput.Reset()
-		m.textInput.Focus()
-		m.state = StateTextInput
-		return m, textinput.Blink
+		m.resetExpect()
+		m.picker = newFilePicker()
+		m.state = StateFilePicker
 	}
 	return m, nil
 }
 
 func (m Model) handleTextInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	stringMode := m.inputMode == InputModeString
+
+	// Enter starts a new line in the multi-line editor; ctrl+s hashes
+	if msg.String() == "enter" && stringMode && m.multiline && !m.expectFocused {
+		return m.updateEditor(msg)
+	}
+
 	switch msg.String() {
 	case "esc":
 		m.textInput.Reset()
+		m.textArea.Reset()
 		m.state = StateInputMode
+		if m.inputMode == InputModeFile {
+			m.state = StateFilePicker
+		}
 		return m, nil
-	case "enter":
-		input := strings.TrimSpace(m.textInput.Value())
+	case "tab", "shift+tab":
+		m.expectFocused = !m.expectFocused
+		if m.expectFocused {
+			m.textInput.Blur()
+			m.textArea.Blur()
+			return m, m.expectInput.Focus()
+		}
+		return m, m.focusInput()
+	case "ctrl+l":
+		m.live = !m.live
+		return m, m.scheduleLive()
+	case "ctrl+t":
+		m.liveMulti = !m.liveMulti
+		return m, m.scheduleLive()
+	case "ctrl+n":
+		if stringMode {
+			m.multiline = !m.multiline
+			if m.multiline {
+				m.textArea.SetValue(m.textInput.Value())
+			} else {
+				m.textInput.SetValue(m.textArea.Value())
+			}
+			var cmd tea.Cmd
+			if !m.expectFocused {
+				cmd = m.focusInput()
+			}
+			return m, tea.Batch(cmd, m.scheduleLive())
+		}
+	case "ctrl+r":
+		if stringMode && m.multiline {
+			m.lineEnding = next(hasher.LineEndings, m.lineEnding)
+			return m, m.scheduleLive()
+		}
+	case "ctrl+x":
+		if stringMode {
+			m.decoder = next(hasher.Decoders, m.decoder)
+			return m, m.scheduleLive()
+		}
+	case "enter", "ctrl+s":
+		return m.submitTextInput()
+	}
+	return m.updateEditor(msg)
+}
+
+// updateEditor passes a key to whichever field has focus
+func (m Model) updateEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.expectFocused {
+		m.expectInput, cmd = m.expectInput.Update(msg)
+		return m, cmd
+	}
+	before := m.rawInput()
+	if m.inputMode == InputModeString && m.multiline {
+		m.textArea, cmd = m.textArea.Update(msg)
+	} else {
+		m.textInput, cmd = m.textInput.Update(msg)
+	}
+	if m.inputMode == InputModeString && m.rawInput() != before {
+		return m, tea.Batch(cmd, m.scheduleLive())
+	}
+	return m, cmd
+}
+
+// submitTextInput hashes the typed string or path
+func (m Model) submitTextInput() (tea.Model, tea.Cmd) {
+	var input string
+	if m.inputMode == InputModeString {
+		var err error
+		input, err = m.stringInput()
+		if m.rawInput() == "" || err != nil {
+			// Decoding errors are already shown under the input
+			return m, nil
+		}
+	} else {
+		input = strings.TrimSpace(m.textInput.Value())
 		if input == "" {
 			return m, nil
 		}
-		m.state = StateHashing
-		m.isHashing = true
-		m.hashStart = time.Now()
+	}
 
-		if m.inputMode == InputModeString {
-			return m, tea.Batch(m.spinner.Tick, m.doHashString(input))
+	m.expected, m.expectErr = nil, nil
+	if raw := strings.TrimSpace(m.expectInput.Value()); raw != "" {
+		e, err := hasher.ParseExpected(raw)
+		if err != nil {
+			m.expectErr = err
+			return m, nil
 		}
+		m.expected = &e
+	}
+	if m.inputMode == InputModeFile {
 		m.files = []string{input}
-		return m, tea.Batch(m.spinner.Tick, m.doHashFiles())
-	default:
-		// IMPORTANT: Pass all other keys to the text input!
-		var cmd tea.Cmd
-		m.textInput, cmd = m.textInput.Update(msg)
-		return m, cmd
+		return m, m.startHashFiles()
 	}
+	m.state = StateHashing
+	m.isHashing = true
+	m.hashStart = time.Now()
+	return m, tea.Batch(m.spinner.Tick, m.doHashString(input))
+}
+
+// resetExpect clears the expected digest field and focuses the main input
+func (m *Model) resetExpect() {
+	m.expectInput.Reset()
+	m.expectInput.Blur()
+	m.expectFocused = false
+	m.expected = nil
+	m.expectErr = nil
 }
 
 func (m Model) handleResults(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status, m.statusErr = "", false
+	if len(m.results) > 1 && m.handleTable(msg) {
+		return m, nil
+	}
+
 	switch msg.String() {
 	case "q":
 		return m, tea.Quit
+	case "c":
+		if r, ok := m.selectedResult(); ok && r.Error == nil {
+			return m, copyToClipboard(r.Hash)
+		}
+	case "v":
+		m.visual = next(visualKinds, m.visual)
+		if len(m.results) > 1 && m.visual != "" {
+			m.table.expanded = true
+		}
+	case "e":
+		if len(m.results) > 0 {
+			m.export = newExportScreen(m.opts.Algorithm, m.results)
+			m.state = StateExport
+			return m, textinput.Blink
+		}
 	case "esc", "r":
 		m.state = StateCategorySelect
-		m.results = nil
+		m.results, m.verdicts = nil, nil
 		m.err = nil
 		m.textInput.Reset()
 		m.files = nil
@@ -289,7 +579,7 @@ func (m Model) handleResults(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.algorithmIndex = 0
 	case "n":
 		// New hash with same algorithm
-		m.results = nil
+		m.results, m.verdicts = nil, nil
 		m.err = nil
 		m.textInput.Reset()
 		m.state = StateInputMode
@@ -300,17 +590,10 @@ func (m Model) handleResults(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m Model) doHashString(input string) tea.Cmd {
 	return func() tea.Msg {
 		result := hasher.HashString(input, m.opts)
-		return hashCompleteMsg{results: []hasher.Result{result}}
-	}
-}
-
-func (m Model) doHashFiles() tea.Cmd {
-	return func() tea.Msg {
-		var results []hasher.Result
-		hasher.HashFiles(m.files, m.opts, func(r hasher.Result) {
-			results = append(results, r)
-		})
-		return hashCompleteMsg{results: results}
+		return hashCompleteMsg{
+			results:  []hasher.Result{result},
+			verdicts: []verdict{judge(m.expected, result, m.opts)},
+		}
 	}
 }
 
@@ -331,6 +614,20 @@ func (m Model) View() string {
 		s.WriteString(m.viewHashing())
 	case StateResults:
 		s.WriteString(m.viewResults())
+	case StateIdentifyInput:
+		s.WriteString(m.viewIdentifyInput())
+	case StateIdentifyResults:
+		s.WriteString(m.viewIdentifyResults())
+	case StateFilePicker:
+		s.WriteString(m.viewFilePicker())
+	case StateHistory:
+		s.WriteString(m.viewHistory())
+	case StateExport:
+		s.WriteString(m.viewExport())
+	case StateSearch:
+		s.WriteString(m.viewSearch())
+	case StateSettings:
+		s.WriteString(m.viewSettings())
 	}
 
 	return AppStyle.Render(s.String())
@@ -344,13 +641,13 @@ func (m Model) viewCategorySelect() string {
 	s.WriteString(LogoAccent.Render(" ⟡"))
 	s.WriteString("\n")
 	s.WriteString(SubtitleStyle.Render("compute cryptographic hashes for strings & files"))
+	if m.policy.Name != hasher.DefaultPolicy {
+		s.WriteString("\n")
+		s.WriteString(MutedStyle.Render("policy " + m.policy.Name))
+	}
 	s.WriteString("\n\n\n")
 
-	categories := []hasher.Category{
-		hasher.CategoryChecksum,
-		hasher.CategoryFastHash,
-		hasher.CategoryPasswordHash,
-	}
+	categories := m.categories()
 
 	for i, cat := range categories {
 		isSelected := m.categoryIndex == i
@@ -369,7 +666,7 @@ func (m Model) viewCategorySelect() string {
 	}
 
 	s.WriteString("\n")
-	s.WriteString(HelpStyle.Render("↑/↓ select • enter confirm • q quit"))
+	s.WriteString(HelpStyle.Render("↑/↓ select • enter confirm • / search • i identify a hash • h history • ctrl+o settings • q quit"))
 
 	return s.String()
 }
@@ -397,6 +694,10 @@ func (m Model) viewAlgorithmSelect() string {
 				s.WriteString("\n")
 				s.WriteString(WarningStyle.Render("  ⚠ slow on large inputs"))
 			}
+			if m.policy.Status(getAlgorithmKey(alg.Name)) == hasher.StatusDeprecated {
+				s.WriteString("\n")
+				s.WriteString(WarningStyle.Render("  ⚠ deprecated under the " + m.policy.Name + " policy"))
+			}
 		} else {
 			s.WriteString(NoCursor())
 			s.WriteString(UnselectedStyle.Render(algName))
@@ -405,7 +706,7 @@ func (m Model) viewAlgorithmSelect() string {
 	}
 
 	s.WriteString("\n")
-	s.WriteString(HelpStyle.Render("↑/↓ select • enter confirm • esc back • q quit"))
+	s.WriteString(HelpStyle.Render("↑/↓ select • enter confirm • / search • esc back • q quit"))
 
 	return s.String()
 }
@@ -424,7 +725,7 @@ func (m Model) viewInputMode() string {
 	s.WriteString(SelectedStyle.Render("s  hash a string"))
 	s.WriteString("\n\n")
 
-	s.WriteString(UnselectedStyle.Render("f  hash a file"))
+	s.WriteString(UnselectedStyle.Render("f  pick files or folders"))
 	s.WriteString("\n\n")
 
 	s.WriteString(HelpStyle.Render("s string • f file • esc back • q quit"))
@@ -451,10 +752,46 @@ func (m Model) viewTextInput() string {
 
 	// Flat input - no box
 	inputView := m.textInput.View()
+	if m.inputMode == InputModeString && m.multiline {
+		inputView = m.textArea.View()
+	}
 	s.WriteString(InputStyle.Render(inputView))
+	s.WriteString("\n")
+
+	if m.inputMode == InputModeString {
+		s.WriteString(InputStyle.Render(m.viewInputStatus()))
+		s.WriteString("\n\n")
+		s.WriteString(InputStyle.Render(m.viewLive()))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	s.WriteString(SubtitleStyle.Render("expected hash:"))
 	s.WriteString("\n\n")
+	s.WriteString(InputStyle.Render(m.expectInput.View()))
+	s.WriteString("\n")
+	if m.expectErr != nil {
+		s.WriteString("\n")
+		s.WriteString(ErrorStyle.Render("✗ " + m.expectErr.Error()))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	if m.inputMode == InputModeFile {
+		s.WriteString(HelpStyle.Render("enter hash • tab switch field • esc back"))
+		return s.String()
+	}
 
-	s.WriteString(HelpStyle.Render("enter hash • esc back"))
+	help := "enter hash • tab switch field • esc back"
+	options := "ctrl+n multi-line • ctrl+x decoder"
+	if m.multiline {
+		help = "ctrl+s hash • enter new line • tab switch field • esc back"
+		options += " • ctrl+r line ending"
+	}
+	if !m.selectedAlgo.IsPasswordHash {
+		options += " • ctrl+l live • ctrl+t side by side"
+	}
+	s.WriteString(HelpStyle.Render(help + "\n" + options))
 
 	return s.String()
 }
@@ -473,11 +810,19 @@ func (m Model) viewHashing() string {
 	elapsed := time.Since(m.hashStart)
 	s.WriteString(DimStyle.Render(fmt.Sprintf("elapsed: %s", elapsed.Round(time.Millisecond))))
 
+	if m.inputMode == InputModeFile && m.hashTotal > 0 {
+		s.WriteString("\n")
+		s.WriteString(MutedStyle.Render(fmt.Sprintf("%d/%d files", len(m.results), m.hashTotal)))
+		s.WriteString("\n\n")
+		s.WriteString(m.viewFileResults(max((m.height-12)/2, 3)))
+	}
+
 	return s.String()
 }
 
 func (m Model) viewResults() string {
 	var s strings.Builder
+	results := m.results
 
 	s.WriteString(LogoStyle.Render("hashctl"))
 	s.WriteString(" ")
@@ -491,7 +836,17 @@ func (m Model) viewResults() string {
 		s.WriteString(LabelStyle.Render(strings.ToUpper(m.selectedAlgo.Name)))
 		s.WriteString("\n\n")
 
-		for _, r := range m.results {
+		if m.inputMode == InputModeFile && len(m.results) != 1 {
+			s.WriteString(m.viewFileSummary())
+			if state := m.table.viewTableState(); state != "" {
+				s.WriteString("\n")
+				s.WriteString(DimStyle.Render(state))
+			}
+			s.WriteString("\n\n")
+			s.WriteString(m.viewTable())
+			results = nil
+		}
+		for i, r := range results {
 			if r.Error != nil {
 				s.WriteString(ErrorStyle.Render("✗ " + r.Input))
 				s.WriteString("\n")
@@ -504,13 +859,22 @@ func (m Model) viewResults() string {
 					s.WriteString(ValueStyle.Render(r.Input))
 				} else {
 					s.WriteString(StringStyle.Render("text: "))
-					s.WriteString(MutedStyle.Render("\"" + truncate(r.Input, 30) + "\""))
+					// Quoted so newlines and binary input stay on one line
+					s.WriteString(MutedStyle.Render(strconv.Quote(truncate(r.Input, 30))))
 				}
 				s.WriteString("\n\n")
 
 				// Hash result - flat, no box
-				s.WriteString(HashStyle.Render(r.Hash))
+				if m.expected != nil {
+					s.WriteString(m.viewComparison(i))
+				} else {
+					s.WriteString(HashStyle.Render(r.Hash))
+				}
 				s.WriteString("\n\n")
+				if m.visual != "" {
+					s.WriteString(m.viewFingerprint(r.Hash, ""))
+					s.WriteString("\n")
+				}
 
 				s.WriteString(DimStyle.Render(fmt.Sprintf("computed in %s", r.Duration.Round(time.Microsecond))))
 				s.WriteString("\n")
@@ -518,13 +882,59 @@ func (m Model) viewResults() string {
 		}
 	}
 
+	if m.historyErr != nil {
+		s.WriteString("\n")
+		s.WriteString(WarningStyle.Render("⚠ history not saved: " + m.historyErr.Error()))
+		s.WriteString("\n")
+	}
+	if m.status != "" {
+		s.WriteString("\n")
+		s.WriteString(m.viewStatus())
+		s.WriteString("\n")
+	}
+
+	help := "c copy • v fingerprint • e export • n new hash • r restart • q quit"
+	if len(m.results) > 1 {
+		help = "↑/↓ select • enter detail • s sort • S reverse • x failures only\n" + help
+	}
 	s.WriteString("\n")
-	s.WriteString(HelpStyle.Render("n new hash • r restart • q quit"))
+	s.WriteString(HelpStyle.Render(help))
 
 	return s.String()
 }
 
 // Helpers
+
+// categories returns the categories with at least one allowed algorithm
+func (m Model) categories() []hasher.Category {
+	all := []hasher.Category{
+		hasher.CategoryChecksum,
+		hasher.CategoryFastHash,
+		hasher.CategoryPasswordHash,
+	}
+	var cats []hasher.Category
+	for _, cat := range all {
+		for _, alg := range hasher.GetAlgorithmsByCategory()[cat] {
+			if m.policy.Allowed(getAlgorithmKey(alg.Name)) {
+				cats = append(cats, cat)
+				break
+			}
+		}
+	}
+	return cats
+}
+
+// algorithmsIn returns the allowed algorithms in a category
+func (m Model) algorithmsIn(cat hasher.Category) []hasher.Algorithm {
+	var algs []hasher.Algorithm
+	for _, alg := range hasher.GetAlgorithmsByCategory()[cat] {
+		if m.policy.Allowed(getAlgorithmKey(alg.Name)) {
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
+
 func getAlgorithmKey(name string) string {
 	for key, alg := range hasher.Registry {
 		if alg.Name == name {
@@ -541,9 +951,11 @@ func truncate(s string, max int) string {
 	return s[:max-3] + "..."
 }
 
-// Run starts the TUI application
-func Run() error {
-	p := tea.NewProgram(NewModel(), tea.WithAltScreen())
+// Run starts the TUI application under the given algorithm policy and
+// settings, recording results in store unless it is nil
+func Run(policy hasher.Policy, store *history.Store, settings Settings) error {
+	detectBackground()
+	p := tea.NewProgram(NewModel(policy, store, settings), tea.WithAltScreen())
 	_, err := p.Run()
 	return err
 }
@@ -0,0 +1,342 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Columns the results table can be sorted by; the empty column keeps the
+// order the files were hashed in
+var sortColumns = []string{"", "path", "size", "duration", "status"}
+
+// resultsTable is the scrollable view of a batch of results
+type resultsTable struct {
+	rows       []int // indexes into the results, filtered and sorted
+	sortBy     string
+	desc       bool
+	errorsOnly bool
+	expanded   bool // show the detail of the cursor row
+	cursor     int
+	offset     int
+}
+
+// refresh rebuilds the rows after the results, sorting or filter changed,
+// keeping the cursor on the same result where it is still shown
+func (t *resultsTable) refresh(m Model) {
+	current := -1
+	if t.cursor < len(t.rows) {
+		current = t.rows[t.cursor]
+	}
+
+	t.rows = t.rows[:0]
+	for i := range m.results {
+		if !t.errorsOnly || m.failed(i) {
+			t.rows = append(t.rows, i)
+		}
+	}
+
+	res := m.results
+	less := func(a, b int) bool { return false }
+	switch t.sortBy {
+	case "path":
+		less = func(a, b int) bool { return res[a].Input < res[b].Input }
+	case "size":
+		less = func(a, b int) bool { return res[a].Size < res[b].Size }
+	case "duration":
+		less = func(a, b int) bool { return res[a].Duration < res[b].Duration }
+	case "status":
+		// Failures first, then mismatches against the expected digest
+		less = func(a, b int) bool { return m.statusRank(a) < m.statusRank(b) }
+	}
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		a, b := t.rows[i], t.rows[j]
+		if t.desc {
+			a, b = b, a
+		}
+		return less(a, b)
+	})
+	if t.desc && t.sortBy == "" {
+		for i, j := 0, len(t.rows)-1; i < j; i, j = i+1, j-1 {
+			t.rows[i], t.rows[j] = t.rows[j], t.rows[i]
+		}
+	}
+
+	t.cursor = 0
+	for i, r := range t.rows {
+		if r == current {
+			t.cursor = i
+		}
+	}
+}
+
+// statusRank orders m.results[i] by status: failed, mismatched, then ok
+func (m Model) statusRank(i int) int {
+	switch {
+	case m.results[i].Error != nil:
+		return 0
+	case m.expected != nil:
+		if v := m.verdictOf(i); v.err != nil || !v.match {
+			return 1
+		}
+	}
+	return 2
+}
+
+// failed reports whether m.results[i] errored or did not match the
+// expected digest
+func (m Model) failed(i int) bool {
+	return m.statusRank(i) < 2
+}
+
+// selectedResult returns the result under the cursor
+func (m Model) selectedResult() (hasher.Result, bool) {
+	t := m.table
+	if t.cursor < 0 || t.cursor >= len(t.rows) || t.rows[t.cursor] >= len(m.results) {
+		return hasher.Result{}, false
+	}
+	return m.results[t.rows[t.cursor]], true
+}
+
+func (m Model) tableRows() int {
+	rows := max(m.height-20, 5)
+	if m.table.expanded {
+		rows = max(rows-5-m.fingerprintHeight(), 3)
+	}
+	return rows
+}
+
+// handleTable moves through the results table and changes its sorting
+// and filter. It reports whether the key was used.
+func (m *Model) handleTable(msg tea.KeyMsg) bool {
+	t := &m.table
+	switch msg.String() {
+	case "up", "k":
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case "down", "j":
+		if t.cursor < len(t.rows)-1 {
+			t.cursor++
+		}
+	case "pgup", "ctrl+u":
+		t.cursor = max(t.cursor-m.tableRows(), 0)
+	case "pgdown", "ctrl+d":
+		t.cursor = max(min(t.cursor+m.tableRows(), len(t.rows)-1), 0)
+	case "home", "g":
+		t.cursor = 0
+	case "end", "G":
+		t.cursor = max(len(t.rows)-1, 0)
+	case "enter", " ":
+		t.expanded = !t.expanded
+	case "s":
+		t.sortBy = next(sortColumns, t.sortBy)
+		t.desc = false
+		t.refresh(*m)
+	case "S":
+		t.desc = !t.desc
+		t.refresh(*m)
+	case "x":
+		t.errorsOnly = !t.errorsOnly
+		t.refresh(*m)
+	default:
+		return false
+	}
+
+	rows := m.tableRows()
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+	if t.cursor >= t.offset+rows {
+		t.offset = t.cursor - rows + 1
+	}
+	return true
+}
+
+// viewTable renders one row per result with the cursor row's detail
+// optionally expanded beneath it
+func (m Model) viewTable() string {
+	var s strings.Builder
+	t := m.table
+
+	// Paths get the room they need; digests get what is left
+	const sizeWidth, timeWidth = 10, 9
+	avail := max(m.width-8-sizeWidth-timeWidth-3, 40)
+	longest := 0
+	for _, i := range t.rows {
+		longest = max(longest, len([]rune(m.results[i].Input)))
+	}
+	pathWidth := min(max(longest, 12), avail-16)
+	hashWidth := avail - pathWidth
+
+	s.WriteString(DimStyle.Render(fmt.Sprintf("    %-*s %*s %*s %s", pathWidth, "path", sizeWidth, "size", timeWidth, "time", "digest")))
+	s.WriteString("\n")
+
+	if len(t.rows) == 0 {
+		if t.errorsOnly {
+			s.WriteString(DimStyle.Render("    no failures"))
+		} else {
+			s.WriteString(DimStyle.Render("    no results"))
+		}
+		s.WriteString("\n")
+	}
+
+	end := min(t.offset+m.tableRows(), len(t.rows))
+	if t.offset > 0 {
+		s.WriteString(DimStyle.Render(fmt.Sprintf("    … %d more", t.offset)))
+		s.WriteString("\n")
+	}
+	for i := t.offset; i < end; i++ {
+		r := m.results[t.rows[i]]
+		selected := i == t.cursor
+		if selected {
+			s.WriteString(Cursor())
+		} else {
+			s.WriteString(NoCursor())
+		}
+
+		rank := m.statusRank(t.rows[i])
+		switch rank {
+		case 0:
+			s.WriteString(ErrorStyle.Render("✗ "))
+		case 1:
+			s.WriteString(ErrorStyle.Render("≠ "))
+		default:
+			s.WriteString(SuccessStyle.Render("✓ "))
+		}
+
+		path := fmt.Sprintf("%-*s", pathWidth, truncatePath(r.Input, pathWidth))
+		if selected {
+			s.WriteString(SelectedStyle.Render(path))
+		} else {
+			s.WriteString(ValueStyle.Render(path))
+		}
+		s.WriteString(" ")
+		if r.Error != nil {
+			s.WriteString(DimStyle.Render(fmt.Sprintf("%*s %*s ", sizeWidth, "-", timeWidth, "-")))
+			s.WriteString(ErrorStyle.Render(truncate(r.Error.Error(), hashWidth)))
+		} else {
+			s.WriteString(MutedStyle.Render(fmt.Sprintf("%*s %*s ", sizeWidth, FormatBytes(r.Size),
+				timeWidth, formatDuration(r.Duration))))
+			s.WriteString(HashStyle.Render(truncate(r.Hash, hashWidth)))
+		}
+		s.WriteString("\n")
+
+		if selected && t.expanded {
+			s.WriteString(m.viewResultDetail(t.rows[i]))
+		}
+	}
+	if hidden := len(t.rows) - end; hidden > 0 {
+		s.WriteString(DimStyle.Render(fmt.Sprintf("    … %d more", hidden)))
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// viewTableState describes the sorting and filter when they are set
+func (t resultsTable) viewTableState() string {
+	var parts []string
+	if t.sortBy != "" {
+		order := "▲"
+		if t.desc {
+			order = "▼"
+		}
+		parts = append(parts, "sorted by "+t.sortBy+" "+order)
+	} else if t.desc {
+		parts = append(parts, "newest first")
+	}
+	if t.errorsOnly {
+		parts = append(parts, "failures only")
+	}
+	return strings.Join(parts, " • ")
+}
+
+// viewResultDetail shows everything known about m.results[i]
+func (m Model) viewResultDetail(i int) string {
+	var s strings.Builder
+	r := m.results[i]
+	indent := "      "
+
+	s.WriteString(indent + MutedStyle.Render("path   ") + ValueStyle.Render(r.Input))
+	s.WriteString("\n")
+	if r.Error != nil {
+		s.WriteString(indent + MutedStyle.Render("error  ") + ErrorStyle.Render(r.Error.Error()))
+		s.WriteString("\n")
+		return s.String()
+	}
+	s.WriteString(indent + MutedStyle.Render("digest ") + HashStyle.Render(r.Hash))
+	s.WriteString("\n")
+	detail := fmt.Sprintf("%s (%d bytes) in %s", FormatBytes(r.Size), r.Size, r.Duration.Round(time.Microsecond))
+	if r.Cached {
+		detail += " • cached"
+	}
+	s.WriteString(indent + MutedStyle.Render("size   ") + DimStyle.Render(detail))
+	s.WriteString("\n")
+	if m.expected != nil {
+		s.WriteString(indent + MutedStyle.Render("expect "))
+		if v := m.verdictOf(i); v.err != nil {
+			s.WriteString(ErrorStyle.Render("✗ " + v.err.Error()))
+		} else {
+			s.WriteString(Verdict(v.match, ""))
+		}
+		s.WriteString("\n")
+	}
+	if m.visual != "" {
+		s.WriteString(m.viewFingerprint(r.Hash, indent))
+	}
+	return s.String()
+}
+
+// viewFileSummary totals a batch: files, bytes, throughput and failures
+func (m Model) viewFileSummary() string {
+	if len(m.results) == 0 {
+		return MutedStyle.Render("no files to hash")
+	}
+	var bytes int64
+	failed, mismatched := 0, 0
+	for i, r := range m.results {
+		switch m.statusRank(i) {
+		case 0:
+			failed++
+			continue
+		case 1:
+			mismatched++
+		}
+		bytes += r.Size
+	}
+
+	summary := fmt.Sprintf("%d files • %s in %s", len(m.results), FormatBytes(bytes), m.hashElapsed.Round(time.Millisecond))
+	if secs := m.hashElapsed.Seconds(); secs > 0 && bytes > 0 {
+		summary += fmt.Sprintf(" • %s/s", FormatBytes(int64(float64(bytes)/secs)))
+	}
+	s := MutedStyle.Render(summary)
+	if failed > 0 {
+		s += MutedStyle.Render(" • ") + ErrorStyle.Render(fmt.Sprintf("%d failed", failed))
+	}
+	if mismatched > 0 {
+		s += MutedStyle.Render(" • ") + ErrorStyle.Render(fmt.Sprintf("%d mismatched", mismatched))
+	}
+	return s
+}
+
+// formatDuration keeps durations short: microseconds below a second,
+// milliseconds above
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// truncatePath shortens a path from the front, keeping the file name
+func truncatePath(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return "..." + string(r[len(r)-max+3:])
+}
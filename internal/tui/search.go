@@ -0,0 +1,246 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/fuzzy"
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	"github.com/atharvamhaske/hashctl/internal/history"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxRecent is how many recently used algorithms are remembered
+const maxRecent = 5
+
+// algoSearch filters every allowed algorithm, whatever its category
+type algoSearch struct {
+	input   textinput.Model
+	matches []hasher.Algorithm
+	// recent is how many leading matches are recently used algorithms;
+	// they are only set apart while the query is empty
+	recent int
+	cursor int
+	offset int
+	// back is the screen the search was opened from
+	back State
+}
+
+// recentAlgorithms returns the algorithm keys found in the history, most
+// recently used first
+func recentAlgorithms(store *history.Store) []string {
+	if store == nil {
+		return nil
+	}
+	entries, err := store.Load()
+	if err != nil {
+		return nil
+	}
+	var keys []string
+	for _, e := range entries {
+		if !slices.Contains(keys, e.Algorithm) {
+			keys = append(keys, e.Algorithm)
+			if len(keys) == maxRecent {
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// openSearch shows the search overlay over the current screen
+func (m Model) openSearch() (tea.Model, tea.Cmd) {
+	si := textinput.New()
+	si.Prompt = "/"
+	si.Placeholder = "name, alias or description"
+	si.CharLimit = 64
+	si.Width = 40
+
+	m.search = algoSearch{input: si, back: m.state}
+	m.filterSearch()
+	m.state = StateSearch
+	return m, m.search.input.Focus()
+}
+
+// filterSearch ranks the allowed algorithms against the query. The best
+// of the key, name and aliases counts; description matches come last.
+func (m *Model) filterSearch() {
+	query := strings.TrimSpace(m.search.input.Value())
+
+	var algs []hasher.Algorithm
+	recent := 0
+	for _, key := range m.recent {
+		if alg, ok := hasher.GetAlgorithm(key); ok && m.policy.Allowed(key) {
+			algs = append(algs, alg)
+			recent++
+		}
+	}
+	for _, alg := range hasher.GetSortedAlgorithms() {
+		key := getAlgorithmKey(alg.Name)
+		if m.policy.Allowed(key) && !slices.Contains(m.recent, key) {
+			algs = append(algs, alg)
+		}
+	}
+
+	s := &m.search
+	s.cursor, s.offset = 0, 0
+	if query == "" {
+		s.matches, s.recent = algs, recent
+		return
+	}
+
+	type hit struct {
+		alg   hasher.Algorithm
+		score int
+	}
+	var hits []hit
+	for _, alg := range algs {
+		best, found := 0, false
+		for _, field := range append([]string{getAlgorithmKey(alg.Name), alg.Name}, alg.Aliases...) {
+			if score, ok := fuzzy.Match(query, field); ok && (!found || score > best) {
+				best, found = score, true
+			}
+		}
+		// Prose matches almost any short pattern loosely, so the
+		// description only counts when it contains the query
+		if !found && strings.Contains(strings.ToLower(alg.Description), strings.ToLower(query)) {
+			best, found = 0, true
+		}
+		if found {
+			hits = append(hits, hit{alg, best})
+		}
+	}
+	// Stable, so recently used algorithms win ties
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].score > hits[j].score
+	})
+	s.matches, s.recent = nil, 0
+	for _, h := range hits {
+		s.matches = append(s.matches, h.alg)
+	}
+}
+
+// selectAlgorithm moves on to the input screen with alg, as if it had been
+// picked from its category
+func (m Model) selectAlgorithm(alg hasher.Algorithm) Model {
+	key := getAlgorithmKey(alg.Name)
+
+	m.selectedCategory = alg.Category
+	m.algorithms = m.algorithmsIn(alg.Category)
+	m.algorithmIndex = max(slices.IndexFunc(m.algorithms, func(a hasher.Algorithm) bool {
+		return a.Name == alg.Name
+	}), 0)
+	m.categoryIndex = max(slices.Index(m.categories(), alg.Category), 0)
+
+	m.selectedAlgo = alg
+	m.opts.Algorithm = key
+	m.recent = append([]string{key}, slices.DeleteFunc(slices.Clone(m.recent), func(k string) bool {
+		return k == key
+	})...)
+	if len(m.recent) > maxRecent {
+		m.recent = m.recent[:maxRecent]
+	}
+	m.state = StateInputMode
+	return m
+}
+
+func (m Model) searchRows() int {
+	return max(m.height-16, 5)
+}
+
+func (m Model) handleSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := &m.search
+
+	switch msg.String() {
+	case "esc":
+		m.state = s.back
+		return m, nil
+	case "enter":
+		if s.cursor < len(s.matches) {
+			return m.selectAlgorithm(s.matches[s.cursor]), nil
+		}
+		return m, nil
+	case "up", "ctrl+p", "shift+tab":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	case "down", "ctrl+n", "tab":
+		if s.cursor < len(s.matches)-1 {
+			s.cursor++
+		}
+	default:
+		var cmd tea.Cmd
+		s.input, cmd = s.input.Update(msg)
+		m.filterSearch()
+		return m, cmd
+	}
+
+	rows := m.searchRows()
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+rows {
+		s.offset = s.cursor - rows + 1
+	}
+	return m, nil
+}
+
+func (m Model) viewSearch() string {
+	var s strings.Builder
+	x := m.search
+
+	s.WriteString(LogoStyle.Render("hashctl"))
+	s.WriteString(LogoAccent.Render(" ⟡ "))
+	s.WriteString(LabelStyle.Render("SEARCH"))
+	s.WriteString("\n\n")
+
+	s.WriteString(InputStyle.Render(x.input.View()))
+	s.WriteString("\n\n")
+
+	if len(x.matches) == 0 {
+		s.WriteString(DimStyle.Render("  no matches"))
+		s.WriteString("\n")
+	}
+
+	end := min(x.offset+m.searchRows(), len(x.matches))
+	for i := x.offset; i < end; i++ {
+		switch {
+		case x.recent > 0 && i == 0:
+			s.WriteString(MutedStyle.Render("recent"))
+			s.WriteString("\n")
+		case x.recent > 0 && i == x.recent:
+			s.WriteString(MutedStyle.Render("all algorithms"))
+			s.WriteString("\n")
+		}
+
+		alg := x.matches[i]
+		name := fmt.Sprintf("%-14s", strings.ToUpper(alg.Name))
+		if i == x.cursor {
+			s.WriteString(Cursor())
+			s.WriteString(SelectedStyle.Render(name))
+		} else {
+			s.WriteString(NoCursor())
+			s.WriteString(UnselectedStyle.Render(name))
+		}
+		s.WriteString(DimStyle.Render(" " + alg.Category.String()))
+		s.WriteString("\n")
+	}
+
+	if x.cursor < len(x.matches) {
+		alg := x.matches[x.cursor]
+		s.WriteString("\n")
+		s.WriteString(DescStyle.Render(alg.Description))
+		if len(alg.Aliases) > 0 {
+			s.WriteString("\n")
+			s.WriteString(DimStyle.Render("  also " + strings.Join(alg.Aliases, ", ")))
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString(HelpStyle.Render("type to filter • ↑/↓ select • enter choose • esc back"))
+
+	return s.String()
+}
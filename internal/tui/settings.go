@@ -0,0 +1,251 @@
+package tui
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/atharvamhaske/hashctl/internal/config"
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Settings connect the TUI to the settings file
+type Settings struct {
+	Path string
+	// File is the settings as saved; Env holds HASHCTL_* variables, which
+	// win over the file
+	File config.Config
+	Env  config.Config
+}
+
+// effective returns every setting with defaults filled in
+func (s Settings) effective() config.Config {
+	defaults := config.Defaults()
+	defaults.Theme = DefaultTheme
+	return defaults.Merge(s.File).Merge(s.Env)
+}
+
+var settingLabels = map[string]string{
+	config.KeyAlgorithm:    "default algorithm",
+	config.KeyFormat:       "output format",
+	config.KeyParallelism:  "parallelism",
+	config.KeyBcryptCost:   "bcrypt cost",
+	config.KeyArgon2Time:   "argon2 iterations",
+	config.KeyArgon2Memory: "argon2 memory",
+	config.KeyArgon2Lanes:  "argon2 lanes",
+	config.KeyTheme:        "theme",
+	config.KeyUpdateCheck:  "check for updates",
+}
+
+var settingHints = map[string]string{
+	config.KeyFormat:      "used by 'hashctl hash'; the TUI always shows hex",
+	config.KeyTheme:       "auto follows the terminal background; add your own as themes/<name>.json next to this file",
+	config.KeyBcryptCost:  "each step doubles the time to hash a password",
+	config.KeyUpdateCheck: "whether 'hashctl version' asks GitHub for a newer release",
+}
+
+// settingsScreen edits the settings file
+type settingsScreen struct {
+	cursor int
+	// back is the screen the settings were opened from
+	back State
+}
+
+// openSettings shows the settings over the current screen
+func (m Model) openSettings() (tea.Model, tea.Cmd) {
+	m.prefs = settingsScreen{back: m.state}
+	m.status = ""
+	m.state = StateSettings
+	return m, nil
+}
+
+// applySettings puts the effective settings into effect, keeping the
+// algorithm currently in use
+func (m *Model) applySettings() error {
+	eff := m.settings.effective()
+	algorithm := m.opts.Algorithm
+	eff.Apply(&m.opts)
+	m.opts.Algorithm = algorithm
+	if err := SetTheme(eff.Theme); err != nil {
+		return err
+	}
+	m.spinner.Style = SpinnerStyle
+	return nil
+}
+
+// settingChoices returns the values a setting cycles through, or nil for
+// numbers
+func (m Model) settingChoices(key string) []string {
+	switch key {
+	case config.KeyAlgorithm:
+		var keys []string
+		for _, alg := range hasher.GetSortedAlgorithms() {
+			k := getAlgorithmKey(alg.Name)
+			if !alg.IsPasswordHash && m.policy.Allowed(k) {
+				keys = append(keys, k)
+			}
+		}
+		return keys
+	case config.KeyFormat:
+		return hasher.Formats
+	case config.KeyTheme:
+		return Themes
+	case config.KeyUpdateCheck:
+		return []string{config.UpdateCheckOn, config.UpdateCheckOff}
+	}
+	return nil
+}
+
+// stepSetting returns the value delta steps away from the current one
+func (m Model) stepSetting(key string, delta int) string {
+	current := m.settings.effective().Get(key)
+	if choices := m.settingChoices(key); choices != nil {
+		i := slices.Index(choices, current)
+		return choices[((i+delta)%len(choices)+len(choices))%len(choices)]
+	}
+
+	n, _ := strconv.Atoi(current)
+	clamp := func(lo, hi int) string {
+		return strconv.Itoa(min(max(n, lo), hi))
+	}
+	switch key {
+	case config.KeyArgon2Memory:
+		// Memory moves in powers of two
+		if delta > 0 {
+			n *= 2
+		} else {
+			n /= 2
+		}
+		return clamp(8, 4*1024*1024)
+	case config.KeyBcryptCost:
+		n += delta
+		return clamp(bcrypt.MinCost, bcrypt.MaxCost)
+	case config.KeyArgon2Lanes:
+		n += delta
+		return clamp(1, 255)
+	case config.KeyArgon2Time:
+		n += delta
+		return clamp(1, 100)
+	}
+	n += delta
+	return clamp(1, 1024)
+}
+
+// changeSetting saves a new value for the setting under the cursor; an
+// empty value restores the default
+func (m Model) changeSetting(value string) Model {
+	key := config.Keys[m.prefs.cursor]
+	file := m.settings.File
+	if err := file.Set(key, value); err != nil {
+		m.status, m.statusErr = err.Error(), true
+		return m
+	}
+	if err := file.Save(m.settings.Path); err != nil {
+		m.status, m.statusErr = "not saved: "+err.Error(), true
+		return m
+	}
+	m.settings.File = file
+	if err := m.applySettings(); err != nil {
+		m.status, m.statusErr = err.Error(), true
+		return m
+	}
+	m.status, m.statusErr = "saved to "+m.settings.Path, false
+	return m
+}
+
+func (m Model) handleSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.prefs
+	m.status = ""
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc", "ctrl+o":
+		m.state = p.back
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(config.Keys)-1 {
+			p.cursor++
+		}
+	case "right", "l", "enter", " ":
+		return m.changeSetting(m.stepSetting(config.Keys[p.cursor], 1)), nil
+	case "left", "h":
+		return m.changeSetting(m.stepSetting(config.Keys[p.cursor], -1)), nil
+	case "d", "backspace":
+		return m.changeSetting(""), nil
+	}
+	return m, nil
+}
+
+// viewSetting renders a setting's value for display
+func viewSetting(key, value string) string {
+	switch key {
+	case config.KeyArgon2Memory:
+		kib, _ := strconv.ParseInt(value, 10, 64)
+		return FormatBytes(kib * 1024)
+	case config.KeyParallelism:
+		if value == strconv.Itoa(runtime.NumCPU()) {
+			return value + " (cpus)"
+		}
+	}
+	return value
+}
+
+func (m Model) viewSettings() string {
+	var s strings.Builder
+	eff := m.settings.effective()
+
+	s.WriteString(LogoStyle.Render("hashctl"))
+	s.WriteString(LogoAccent.Render(" ⟡ "))
+	s.WriteString(LabelStyle.Render("SETTINGS"))
+	s.WriteString("\n")
+	s.WriteString(DimStyle.Render(m.settings.Path))
+	s.WriteString("\n\n")
+
+	for i, key := range config.Keys {
+		selected := i == m.prefs.cursor
+		label := fmt.Sprintf("%-20s", settingLabels[key])
+		value := viewSetting(key, eff.Get(key))
+		if selected {
+			s.WriteString(Cursor())
+			s.WriteString(SelectedStyle.Render(label))
+			s.WriteString(ValueStyle.Render(fmt.Sprintf("‹ %-14s ›", value)))
+		} else {
+			s.WriteString(NoCursor())
+			s.WriteString(UnselectedStyle.Render(label))
+			s.WriteString(ValueStyle.Render(fmt.Sprintf("  %-14s  ", value)))
+		}
+
+		switch {
+		case key == config.KeyTheme && NoColor():
+			s.WriteString(WarningStyle.Render("  monochrome: NO_COLOR is set"))
+		case m.settings.Env.Get(key) != "":
+			s.WriteString(WarningStyle.Render("  set by " + config.EnvVar(key)))
+		case m.settings.File.Get(key) == "":
+			s.WriteString(DimStyle.Render("  default"))
+		}
+		s.WriteString("\n")
+	}
+
+	if hint := settingHints[config.Keys[m.prefs.cursor]]; hint != "" {
+		s.WriteString("\n")
+		s.WriteString(DescStyle.Render(hint))
+		s.WriteString("\n")
+	}
+	if m.status != "" {
+		s.WriteString("\n")
+		s.WriteString(m.viewStatus())
+		s.WriteString("\n")
+	}
+
+	s.WriteString(HelpStyle.Render("↑/↓ select • ←/→ change • d default • esc back\nflags and HASHCTL_* variables override these settings"))
+
+	return s.String()
+}
@@ -4,146 +4,203 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Neon magenta/purple theme - flat, no boxes
+// Palette of the active theme - set by applyTheme, see theme.go
 var (
-	// Primary accent - neon magenta/purple
-	ColorPrimary = lipgloss.Color("#FF4ECD")
-	ColorAccent  = lipgloss.Color("#C77DFF")
-
-	// Secondary colors
-	ColorCyan   = lipgloss.Color("#89DDFF")
-	ColorGreen  = lipgloss.Color("#C3E88D")
-	ColorYellow = lipgloss.Color("#FFCB6B")
-	ColorOrange = lipgloss.Color("#F78C6C")
-	ColorRed    = lipgloss.Color("#FF5370")
-
-	// Grayscale - muted lavender/gray-purple
-	ColorWhite  = lipgloss.Color("#FFFFFF")
-	ColorFg     = lipgloss.Color("#E0D4FF")
-	ColorMuted  = lipgloss.Color("#8B7FA8")
-	ColorDim    = lipgloss.Color("#5C5478")
+	// Primary accent
+	ColorPrimary lipgloss.TerminalColor
+	ColorAccent  lipgloss.TerminalColor
+
+	// Status colors
+	ColorGreen  lipgloss.TerminalColor
+	ColorYellow lipgloss.TerminalColor
+	ColorOrange lipgloss.TerminalColor
+	ColorRed    lipgloss.TerminalColor
+
+	// Text, from brightest to faintest
+	ColorFg    lipgloss.TerminalColor
+	ColorMuted lipgloss.TerminalColor
+	ColorDim   lipgloss.TerminalColor
 )
 
 // Logo and branding
 var (
-	LogoStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary)
-
-	LogoAccent = lipgloss.NewStyle().
-			Foreground(ColorAccent)
+	LogoStyle  lipgloss.Style
+	LogoAccent lipgloss.Style
 )
 
 // Text styles
 var (
+	TitleStyle    lipgloss.Style
+	SubtitleStyle lipgloss.Style
+	LabelStyle    lipgloss.Style
+	ValueStyle    lipgloss.Style
+	MutedStyle    lipgloss.Style
+	DimStyle      lipgloss.Style
+)
+
+// Category/Algorithm styles - flat, no boxes
+var (
+	BigSelectedStyle     lipgloss.Style
+	BigUnselectedStyle   lipgloss.Style
+	SelectedStyle        lipgloss.Style
+	UnselectedStyle      lipgloss.Style
+	DescStyle            lipgloss.Style
+	CategoryStyle        lipgloss.Style
+	WarningCategoryStyle lipgloss.Style
+)
+
+// Status styles
+var (
+	SuccessStyle lipgloss.Style
+	ErrorStyle   lipgloss.Style
+	WarningStyle lipgloss.Style
+	SpinnerStyle lipgloss.Style
+)
+
+// Input styles - minimal, no box
+var (
+	InputStyle      lipgloss.Style
+	InputLabelStyle lipgloss.Style
+)
+
+// Result styles - flat
+var (
+	HashStyle   lipgloss.Style
+	FileStyle   lipgloss.Style
+	StringStyle lipgloss.Style
+)
+
+// Help bar
+var (
+	HelpStyle lipgloss.Style
+)
+
+// App container - minimal padding
+var (
+	AppStyle = lipgloss.NewStyle().
+		Padding(1, 2)
+)
+
+func init() {
+	applyTheme(darkTheme)
+}
+
+// applyTheme rebuilds every style from a theme. Themes without colours
+// lean on bold, underline and faint text instead.
+func applyTheme(t Theme) {
+	ColorPrimary = t.color(t.Primary)
+	ColorAccent = t.color(t.Accent)
+	ColorGreen = t.color(t.Success)
+	ColorYellow = t.color(t.Warning)
+	ColorOrange = t.color(t.Caution)
+	ColorRed = t.color(t.Error)
+	ColorFg = t.color(t.Text)
+	ColorMuted = t.color(t.Muted)
+	ColorDim = t.color(t.Dim)
+	mono := t.Monochrome
+
+	LogoStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary)
+
+	LogoAccent = lipgloss.NewStyle().
+		Foreground(ColorAccent)
+
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary)
+		Bold(true).
+		Foreground(ColorPrimary)
 
 	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted)
+		Foreground(ColorMuted)
 
 	LabelStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	ValueStyle = lipgloss.NewStyle().
-			Foreground(ColorFg)
+		Foreground(ColorFg)
 
 	MutedStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted)
+		Foreground(ColorMuted)
 
 	DimStyle = lipgloss.NewStyle().
-			Foreground(ColorDim)
-)
+		Foreground(ColorDim).
+		Faint(mono)
 
-// Category/Algorithm styles - flat, no boxes
-var (
-	// Selected item - bright magenta, no background
+	// Selected item - bright, no background
 	BigSelectedStyle = lipgloss.NewStyle().
-				Foreground(ColorPrimary).
-				Bold(true).
-				PaddingLeft(2)
+		Foreground(ColorPrimary).
+		Bold(true).
+		Underline(mono).
+		PaddingLeft(2)
 
 	// Unselected item - muted, no background
 	BigUnselectedStyle = lipgloss.NewStyle().
-				Foreground(ColorMuted).
-				PaddingLeft(2)
+		Foreground(ColorMuted).
+		PaddingLeft(2)
 
 	// Algorithm list styles - flat
 	SelectedStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true)
+		Foreground(ColorPrimary).
+		Bold(true).
+		Underline(mono)
 
 	UnselectedStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted)
+		Foreground(ColorMuted)
 
 	DescStyle = lipgloss.NewStyle().
-			Foreground(ColorDim).
-			Italic(true).
-			PaddingLeft(4)
+		Foreground(ColorDim).
+		Italic(true).
+		Faint(mono).
+		PaddingLeft(4)
 
 	CategoryStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	WarningCategoryStyle = lipgloss.NewStyle().
-				Foreground(ColorOrange).
-				Bold(true)
-)
+		Foreground(ColorOrange).
+		Bold(true).
+		Underline(mono)
 
-// Status styles
-var (
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(ColorGreen).
-			Bold(true)
+		Foreground(ColorGreen).
+		Bold(true)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(ColorRed).
-			Bold(true)
+		Foreground(ColorRed).
+		Bold(true).
+		Reverse(mono)
 
 	WarningStyle = lipgloss.NewStyle().
-			Foreground(ColorYellow)
+		Foreground(ColorYellow).
+		Bold(mono)
 
 	SpinnerStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent)
-)
+		Foreground(ColorAccent)
 
-// Input styles - minimal, no box
-var (
 	InputStyle = lipgloss.NewStyle().
-			Foreground(ColorFg).
-			Bold(true)
+		Foreground(ColorFg).
+		Bold(true)
 
 	InputLabelStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent)
-)
+		Foreground(ColorAccent)
 
-// Result styles - flat
-var (
 	HashStyle = lipgloss.NewStyle().
-			Foreground(ColorGreen).
-			Bold(true)
+		Foreground(ColorGreen).
+		Bold(true)
 
 	FileStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent)
+		Foreground(ColorAccent)
 
 	StringStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary)
-)
+		Foreground(ColorPrimary)
 
-// Help bar
-var (
 	HelpStyle = lipgloss.NewStyle().
-			Foreground(ColorDim).
-			MarginTop(2)
-)
-
-// App container - minimal padding
-var (
-	AppStyle = lipgloss.NewStyle().
-			Padding(1, 2)
-)
+		Foreground(ColorDim).
+		Faint(mono).
+		MarginTop(2)
+}
 
 // Cursor for selection
 func Cursor() string {
@@ -0,0 +1,261 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme names. Auto picks dark or light from the terminal background.
+const (
+	ThemeAuto         = "auto"
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	ThemeMonochrome   = "monochrome"
+)
+
+// DefaultTheme is used when no theme is set
+const DefaultTheme = ThemeAuto
+
+// Theme is a palette for the styles in styles.go. Colours are hex values
+// such as "#FF4ECD" or ANSI numbers such as "13"; an empty colour uses the
+// terminal's own.
+type Theme struct {
+	// Base names the theme a user theme file starts from; colours it
+	// leaves out come from the base
+	Base string `json:"base,omitempty"`
+
+	Primary string `json:"primary,omitempty"`
+	Accent  string `json:"accent,omitempty"`
+	Success string `json:"success,omitempty"`
+	Warning string `json:"warning,omitempty"`
+	Caution string `json:"caution,omitempty"` // weak or deprecated algorithms
+	Error   string `json:"error,omitempty"`
+	Text    string `json:"text,omitempty"`
+	Muted   string `json:"muted,omitempty"`
+	Dim     string `json:"dim,omitempty"`
+
+	// Monochrome drops every colour and tells states apart with bold,
+	// underlined, reversed and faint text
+	Monochrome bool `json:"monochrome,omitempty"`
+}
+
+// Neon magenta/purple on dark backgrounds - the original hashctl look
+var darkTheme = Theme{
+	Primary: "#FF4ECD",
+	Accent:  "#C77DFF",
+	Success: "#C3E88D",
+	Warning: "#FFCB6B",
+	Caution: "#F78C6C",
+	Error:   "#FF5370",
+	Text:    "#E0D4FF",
+	Muted:   "#8B7FA8",
+	Dim:     "#5C5478",
+}
+
+// The same hues, darkened to stay readable on light backgrounds
+var lightTheme = Theme{
+	Primary: "#B0008A",
+	Accent:  "#6A1FB0",
+	Success: "#2E7D32",
+	Warning: "#8A6100",
+	Caution: "#B34700",
+	Error:   "#C62828",
+	Text:    "#2A2238",
+	Muted:   "#5E5470",
+	Dim:     "#857B96",
+}
+
+// Okabe-Ito colours, which stay distinct with the common forms of colour
+// blindness, and bright text throughout
+var highContrastTheme = Theme{
+	Primary: "#56B4E9",
+	Accent:  "#F0E442",
+	Success: "#009E73",
+	Warning: "#F0E442",
+	Caution: "#E69F00",
+	Error:   "#D55E00",
+	Text:    "#FFFFFF",
+	Muted:   "#D0D0D0",
+	Dim:     "#A8A8A8",
+}
+
+var monochromeTheme = Theme{Monochrome: true}
+
+var builtinThemes = map[string]Theme{
+	ThemeDark:         darkTheme,
+	ThemeLight:        lightTheme,
+	ThemeHighContrast: highContrastTheme,
+	ThemeMonochrome:   monochromeTheme,
+}
+
+// userThemes are the themes loaded by LoadThemes
+var userThemes = map[string]Theme{}
+
+// Themes lists the themes SetTheme accepts, built-in ones first
+var Themes = []string{ThemeAuto, ThemeDark, ThemeLight, ThemeHighContrast, ThemeMonochrome}
+
+// color converts a theme colour for lipgloss
+func (t Theme) color(c string) lipgloss.TerminalColor {
+	if t.Monochrome || c == "" {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(c)
+}
+
+// ThemeDir returns where user themes are kept: a themes directory next to
+// the settings file
+func ThemeDir(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "themes")
+}
+
+// LoadThemes adds the *.json theme files in dir to Themes, named after the
+// file. A missing directory has no themes; broken files are skipped and
+// reported together.
+func LoadThemes(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		t, err := loadTheme(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("theme %s: %w", path, err))
+			continue
+		}
+		if _, ok := builtinThemes[name]; ok || name == ThemeAuto {
+			errs = append(errs, fmt.Errorf("theme %s: %q is a built-in theme", path, name))
+			continue
+		}
+		if !slices.Contains(Themes, name) {
+			Themes = append(Themes, name)
+		}
+		userThemes[name] = t
+	}
+	return errors.Join(errs...)
+}
+
+// loadTheme reads a theme file, filling in the colours it leaves out from
+// its base theme
+func loadTheme(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	var t Theme
+	if err := json.Unmarshal(data, &t); err != nil {
+		return Theme{}, err
+	}
+	if t.Base == "" {
+		t.Base = ThemeDark
+	}
+	base, ok := builtinThemes[t.Base]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown base theme %q", t.Base)
+	}
+
+	fill := func(c *string, from string) {
+		if *c == "" {
+			*c = from
+		}
+	}
+	fill(&t.Primary, base.Primary)
+	fill(&t.Accent, base.Accent)
+	fill(&t.Success, base.Success)
+	fill(&t.Warning, base.Warning)
+	fill(&t.Caution, base.Caution)
+	fill(&t.Error, base.Error)
+	fill(&t.Text, base.Text)
+	fill(&t.Muted, base.Muted)
+	fill(&t.Dim, base.Dim)
+	t.Monochrome = t.Monochrome || base.Monochrome
+	return t, nil
+}
+
+// NoColor reports whether colour is turned off with NO_COLOR
+// (https://no-color.org)
+func NoColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// SetTheme switches the styles to a theme; an empty name selects the
+// default. With NO_COLOR set the monochrome theme is used whatever the name,
+// so bold and underlined text still mark the selection on a terminal.
+// Output that is not a terminal is never styled.
+func SetTheme(name string) error {
+	if name == "" {
+		name = DefaultTheme
+	}
+	t, ok := builtinThemes[name]
+	if !ok {
+		t, ok = userThemes[name]
+	}
+	if !ok && name != ThemeAuto {
+		return fmt.Errorf("unknown theme %q (use %s)", name, strings.Join(Themes, ", "))
+	}
+
+	switch {
+	case NoColor():
+		t = monochromeTheme
+		// lipgloss drops bold and underline along with colour under
+		// NO_COLOR; only the colours are unwanted
+		if IsTerminal(os.Stdout) {
+			lipgloss.SetColorProfile(termenv.ANSI)
+		}
+	case name == ThemeAuto:
+		t = darkTheme
+		if !hasDarkBackground() {
+			t = lightTheme
+		}
+	}
+
+	applyTheme(t)
+	return nil
+}
+
+// darkBackgroundHint guesses the background from COLORFGBG, set by
+// terminals such as rxvt and Konsole, assuming dark without it. Asking the
+// terminal itself can stall for seconds when it does not answer, which the
+// one-shot commands should not risk.
+func darkBackgroundHint() bool {
+	fgbg := os.Getenv("COLORFGBG")
+	bg, err := strconv.Atoi(fgbg[strings.LastIndex(fgbg, ";")+1:])
+	if err != nil {
+		return true
+	}
+	// Black and the dark ANSI colours apart from white (7)
+	return bg < 7 || bg == 8
+}
+
+// hasDarkBackground decides the auto theme; it uses the hint until
+// detectBackground has asked the terminal
+var hasDarkBackground = darkBackgroundHint
+
+// detectBackground asks the terminal for its background colour. The TUI
+// does this once before it starts, when a short wait goes unnoticed.
+func detectBackground() {
+	if NoColor() || lipgloss.ColorProfile() == termenv.Ascii {
+		return
+	}
+	dark := lipgloss.HasDarkBackground()
+	hasDarkBackground = func() bool { return dark }
+}
+
+// IsTerminal reports whether f is a character device such as a terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
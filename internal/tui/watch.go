@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/watch"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxWatchLog is how many recent changes the watch screen keeps
+const maxWatchLog = 8
+
+// WatchModel shows watched files with their live digests
+type WatchModel struct {
+	watcher   *watch.Watcher
+	algorithm string
+
+	paths   []string
+	digests map[string]string
+	changed map[string]time.Time
+	log     []watch.Event
+	offset  int
+
+	width  int
+	height int
+}
+
+type watchEventMsg watch.Event
+
+type watchClosedMsg struct{}
+
+type watchTickMsg struct{}
+
+// NewWatchModel creates the live watch screen
+func NewWatchModel(w *watch.Watcher, algorithm string) WatchModel {
+	return WatchModel{
+		watcher:   w,
+		algorithm: algorithm,
+		paths:     w.Paths(),
+		digests:   w.Snapshot(),
+		changed:   make(map[string]time.Time),
+		width:     80,
+		height:    24,
+	}
+}
+
+// Init starts listening for watcher events
+func (m WatchModel) Init() tea.Cmd {
+	return tea.Batch(m.waitForEvent(), watchTick())
+}
+
+func (m WatchModel) waitForEvent() tea.Cmd {
+	return func() tea.Msg {
+		e, ok := <-m.watcher.Events()
+		if !ok {
+			return watchClosedMsg{}
+		}
+		return watchEventMsg(e)
+	}
+}
+
+// watchTick refreshes the screen so change highlights fade out
+func watchTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return watchTickMsg{}
+	})
+}
+
+// Update handles watcher events and navigation
+func (m WatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.offset > 0 {
+				m.offset--
+			}
+		case "down", "j":
+			if m.offset < len(m.paths)-1 {
+				m.offset++
+			}
+		}
+
+	case watchTickMsg:
+		return m, watchTick()
+
+	case watchClosedMsg:
+		return m, tea.Quit
+
+	case watchEventMsg:
+		e := watch.Event(msg)
+		if e.Error == nil {
+			if e.New == "" {
+				delete(m.digests, e.Path)
+			} else {
+				m.digests[e.Path] = e.New
+			}
+			m.paths = m.watcher.Paths()
+		}
+		m.changed[e.Path] = e.Time
+		m.log = append(m.log, e)
+		if len(m.log) > maxWatchLog {
+			m.log = m.log[len(m.log)-maxWatchLog:]
+		}
+		return m, m.waitForEvent()
+	}
+	return m, nil
+}
+
+// View renders the watched files and recent changes
+func (m WatchModel) View() string {
+	var s strings.Builder
+
+	s.WriteString(LogoStyle.Render("hashctl"))
+	s.WriteString(LogoAccent.Render(" ⟡ "))
+	s.WriteString(LabelStyle.Render("WATCH " + strings.ToUpper(m.algorithm)))
+	s.WriteString("\n")
+	s.WriteString(SubtitleStyle.Render(fmt.Sprintf("%d files", len(m.paths))))
+	s.WriteString("\n\n")
+
+	rows := m.height - 12 - len(m.log)
+	if rows < 3 {
+		rows = 3
+	}
+	end := m.offset + rows
+	if end > len(m.paths) {
+		end = len(m.paths)
+	}
+
+	digestWidth := 16
+	pathWidth := max(m.width-digestWidth-10, 20)
+	for _, p := range m.paths[min(m.offset, len(m.paths)):end] {
+		digest := truncate(m.digests[p], digestWidth)
+		line := fmt.Sprintf("%-*s  ", pathWidth, truncate(p, pathWidth))
+		if t, ok := m.changed[p]; ok && time.Since(t) < 5*time.Second {
+			s.WriteString(SelectedStyle.Render(line))
+			s.WriteString(HashStyle.Render(digest))
+		} else {
+			s.WriteString(ValueStyle.Render(line))
+			s.WriteString(DimStyle.Render(digest))
+		}
+		s.WriteString("\n")
+	}
+
+	if len(m.log) > 0 {
+		s.WriteString("\n")
+		for _, e := range m.log {
+			s.WriteString(DimStyle.Render(e.Time.Format("15:04:05") + " "))
+			s.WriteString(watchEventLine(e, 12))
+			s.WriteString("\n")
+		}
+	}
+
+	s.WriteString(HelpStyle.Render("↑/↓ scroll • q quit"))
+
+	return AppStyle.Render(s.String())
+}
+
+// watchEventLine describes a change, shortening digests to n characters
+func watchEventLine(e watch.Event, n int) string {
+	short := func(d string) string {
+		if n > 0 && len(d) > n {
+			return d[:n]
+		}
+		return d
+	}
+
+	switch {
+	case e.Path == "":
+		return WarningStyle.Render("⚠ " + e.Error.Error())
+	case e.Error != nil:
+		return ErrorStyle.Render("✗ "+e.Path) + " " + MutedStyle.Render(e.Error.Error())
+	case e.Old == "":
+		return SuccessStyle.Render("+ "+e.Path) + " " + HashStyle.Render(short(e.New))
+	case e.New == "":
+		return ErrorStyle.Render("- "+e.Path) + " " + DimStyle.Render(short(e.Old))
+	default:
+		return WarningStyle.Render("~ "+e.Path) + " " + DimStyle.Render(short(e.Old)) +
+			MutedStyle.Render(" → ") + HashStyle.Render(short(e.New))
+	}
+}
+
+// WatchEventLine renders a change with full digests for the CLI
+func WatchEventLine(e watch.Event) string {
+	return watchEventLine(e, 0)
+}
+
+// RunWatch starts the live watch screen
+func RunWatch(w *watch.Watcher, algorithm string) error {
+	p := tea.NewProgram(NewWatchModel(w, algorithm), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
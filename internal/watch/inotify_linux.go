@@ -0,0 +1,168 @@
+package watch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_MODIFY | syscall.IN_CREATE |
+	syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO
+
+// inotify watches directories with Linux inotify
+type inotify struct {
+	file *os.File
+	out  chan string
+	done chan struct{}
+
+	mu        sync.Mutex
+	dirs      map[int32]string
+	recursive map[int32]bool
+}
+
+func newNotifier() (notifier, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+
+	// A non-blocking fd is handled by the runtime poller, so closing the
+	// file unblocks the reader goroutine
+	n := &inotify{
+		file:      os.NewFile(uintptr(fd), "inotify"),
+		out:       make(chan string, 256),
+		done:      make(chan struct{}),
+		dirs:      make(map[int32]string),
+		recursive: make(map[int32]bool),
+	}
+	go n.read()
+	return n, nil
+}
+
+func (n *inotify) watchDir(dir string, recursive bool) error {
+	if !recursive {
+		return n.add(dir, false)
+	}
+	return filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return n.add(p, true)
+		}
+		return nil
+	})
+}
+
+func (n *inotify) add(dir string, recursive bool) error {
+	// File.Fd would put the descriptor back into blocking mode, after
+	// which closing the file no longer wakes the reader
+	rc, err := n.file.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var wd int
+	var addErr error
+	if err := rc.Control(func(fd uintptr) {
+		wd, addErr = syscall.InotifyAddWatch(int(fd), dir, inotifyMask)
+	}); err != nil {
+		return err
+	}
+	if addErr != nil {
+		return &os.PathError{Op: "inotify_add_watch", Path: dir, Err: addErr}
+	}
+
+	n.mu.Lock()
+	n.dirs[int32(wd)] = dir
+	n.recursive[int32(wd)] = recursive
+	n.mu.Unlock()
+	return nil
+}
+
+func (n *inotify) changes() <-chan string {
+	return n.out
+}
+
+func (n *inotify) close() error {
+	close(n.done)
+	return n.file.Close()
+}
+
+// send delivers a path unless the notifier has been closed
+func (n *inotify) send(path string) {
+	select {
+	case n.out <- path:
+	case <-n.done:
+	}
+}
+
+func (n *inotify) read() {
+	defer close(n.out)
+
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		count, err := n.file.Read(buf)
+		if err != nil {
+			if errors.Is(err, os.ErrClosed) {
+				return
+			}
+			continue
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= count; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+syscall.SizeofInotifyEvent : offset+syscall.SizeofInotifyEvent+int(ev.Len)]
+			offset += syscall.SizeofInotifyEvent + int(ev.Len)
+
+			if ev.Mask&syscall.IN_Q_OVERFLOW != 0 {
+				n.send(rescan)
+				continue
+			}
+
+			n.mu.Lock()
+			dir, ok := n.dirs[ev.Wd]
+			recursive := n.recursive[ev.Wd]
+			if ev.Mask&syscall.IN_IGNORED != 0 {
+				delete(n.dirs, ev.Wd)
+				delete(n.recursive, ev.Wd)
+			}
+			n.mu.Unlock()
+			if !ok || ev.Len == 0 {
+				continue
+			}
+
+			name := string(nameBytes)
+			for i, c := range nameBytes {
+				if c == 0 {
+					name = string(nameBytes[:i])
+					break
+				}
+			}
+			path := filepath.Join(dir, name)
+
+			if ev.Mask&syscall.IN_ISDIR != 0 {
+				// Follow directories created or moved into a recursive watch
+				if recursive && ev.Mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 {
+					n.watchDir(path, true)
+					n.emitTree(path)
+				}
+				continue
+			}
+			n.send(path)
+		}
+	}
+}
+
+// emitTree reports files that appeared inside a newly watched directory
+// before its watch was in place
+func (n *inotify) emitTree(dir string) {
+	filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err == nil && d.Type().IsRegular() {
+			n.send(p)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,290 @@
+// Package watch re-hashes files as they change on disk
+package watch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/atharvamhaske/hashctl/internal/hasher"
+)
+
+// DefaultDebounce is how long a file must be quiet before it is re-hashed
+const DefaultDebounce = 300 * time.Millisecond
+
+// ErrOverflow is reported when the kernel dropped change notifications;
+// every watched file is hashed again to catch up
+var ErrOverflow = errors.New("change notifications were lost, rescanning")
+
+// rescan is sent by a notifier in place of a path when it lost events
+const rescan = ""
+
+// Event reports the digest of a watched file changing
+type Event struct {
+	Path  string
+	Old   string // empty for files seen for the first time
+	New   string // empty when the file was removed
+	Error error
+	Time  time.Time
+}
+
+// notifier is the platform file change backend
+type notifier interface {
+	// watchDir watches a directory, recursively when recursive is set
+	watchDir(dir string, recursive bool) error
+	// changes delivers paths of files that were written, created, moved or
+	// removed, or rescan when some of them were lost
+	changes() <-chan string
+	close() error
+}
+
+// Watcher hashes a set of paths and reports digest changes
+type Watcher struct {
+	opts     hasher.Options
+	debounce time.Duration
+	notify   notifier
+
+	// files limits events from a non-recursive parent directory watch to
+	// the files named on the command line
+	files map[string]bool
+	dirs  []string
+
+	mu      sync.Mutex
+	digests map[string]string
+
+	events chan Event
+	done   chan struct{}
+}
+
+// New starts watching paths. Directories are watched recursively; for
+// files the parent directory is watched so atomic replacements by editors
+// and build tools are seen. The initial digests are available from
+// Snapshot before any events arrive.
+func New(paths []string, opts hasher.Options, debounce time.Duration) (*Watcher, error) {
+	n, err := newNotifier()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		opts:     opts,
+		debounce: debounce,
+		notify:   n,
+		files:    make(map[string]bool),
+		digests:  make(map[string]string),
+		events:   make(chan Event, 64),
+		done:     make(chan struct{}),
+	}
+
+	var initial []string
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			n.close()
+			return nil, err
+		}
+		info, err := os.Stat(abs)
+		if err != nil {
+			n.close()
+			return nil, err
+		}
+
+		if info.IsDir() {
+			if err := n.watchDir(abs, true); err != nil {
+				n.close()
+				return nil, err
+			}
+			w.dirs = append(w.dirs, abs)
+			files, err := hasher.WalkFiles(abs)
+			if err != nil {
+				n.close()
+				return nil, err
+			}
+			initial = append(initial, files...)
+			continue
+		}
+
+		if err := n.watchDir(filepath.Dir(abs), false); err != nil {
+			n.close()
+			return nil, err
+		}
+		w.files[abs] = true
+		initial = append(initial, abs)
+	}
+
+	hasher.HashFiles(initial, opts, func(r hasher.Result) {
+		if r.Error == nil {
+			w.digests[r.Input] = r.Hash
+		}
+	})
+
+	go w.loop()
+	return w, nil
+}
+
+// Snapshot returns the current digest of every watched file
+func (w *Watcher) Snapshot() map[string]string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	out := make(map[string]string, len(w.digests))
+	for k, v := range w.digests {
+		out[k] = v
+	}
+	return out
+}
+
+// Paths returns the watched files in sorted order
+func (w *Watcher) Paths() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	paths := make([]string, 0, len(w.digests))
+	for p := range w.digests {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Events delivers digest changes until Close is called
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// Close stops watching and closes the event channel
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.notify.close()
+}
+
+// watched reports whether a changed path belongs to the watch set
+func (w *Watcher) watched(path string) bool {
+	if w.files[path] {
+		return true
+	}
+	for _, d := range w.dirs {
+		if strings.HasPrefix(path, d+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// loop collects change notifications and re-hashes once they go quiet
+func (w *Watcher) loop() {
+	defer close(w.events)
+
+	pending := make(map[string]bool)
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case p, ok := <-w.notify.changes():
+			if !ok {
+				return
+			}
+			if p == rescan {
+				w.emit(Event{Error: ErrOverflow, Time: time.Now()})
+				for _, p := range w.rescanPaths() {
+					pending[p] = true
+				}
+				timer.Reset(w.debounce)
+				continue
+			}
+			if !w.watched(p) {
+				continue
+			}
+			pending[p] = true
+			timer.Reset(w.debounce)
+		case <-timer.C:
+			paths := make([]string, 0, len(pending))
+			for p := range pending {
+				paths = append(paths, p)
+			}
+			pending = make(map[string]bool)
+			sort.Strings(paths)
+			w.rehash(paths)
+		}
+	}
+}
+
+// rescanPaths returns every file that may have changed while events were
+// lost: the known ones, the ones named on the command line and everything
+// below the watched directories, whose watches are renewed to pick up new
+// subdirectories
+func (w *Watcher) rescanPaths() []string {
+	seen := make(map[string]bool)
+	w.mu.Lock()
+	for p := range w.digests {
+		seen[p] = true
+	}
+	w.mu.Unlock()
+	for p := range w.files {
+		seen[p] = true
+	}
+	for _, d := range w.dirs {
+		w.notify.watchDir(d, true)
+		files, _ := hasher.WalkFiles(d)
+		for _, p := range files {
+			seen[p] = true
+		}
+	}
+
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// rehash hashes changed files and emits an event for every digest change
+func (w *Watcher) rehash(paths []string) {
+	var present []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err == nil && info.Mode().IsRegular() {
+			present = append(present, p)
+			continue
+		}
+
+		w.mu.Lock()
+		old, known := w.digests[p]
+		delete(w.digests, p)
+		w.mu.Unlock()
+		if known {
+			w.emit(Event{Path: p, Old: old, Time: time.Now()})
+		}
+	}
+
+	hasher.HashFiles(present, w.opts, func(r hasher.Result) {
+		w.mu.Lock()
+		old := w.digests[r.Input]
+		if r.Error == nil {
+			w.digests[r.Input] = r.Hash
+		}
+		w.mu.Unlock()
+
+		if r.Error != nil {
+			w.emit(Event{Path: r.Input, Old: old, Error: r.Error, Time: time.Now()})
+			return
+		}
+		if r.Hash != old {
+			w.emit(Event{Path: r.Input, Old: old, New: r.Hash, Time: time.Now()})
+		}
+	})
+}
+
+func (w *Watcher) emit(e Event) {
+	select {
+	case w.events <- e:
+	case <-w.done:
+	}
+}